@@ -23,7 +23,36 @@ type Binder interface {
 }
 
 // DefaultBinder is the default implementation of the Binder interface.
-type DefaultBinder struct{}
+type DefaultBinder struct {
+	// Decoders are request body decoders by media type. They have priority over Echo.Decoders.
+	Decoders map[string]BodyDecoder
+	// CollectAllErrors makes Bind to continue binding after a field fails to bind and to return BindingErrors with
+	// errors of all fields that failed to bind from path values, query params and form. Request level errors (i.e.
+	// unsupported media type or malformed body) are still returned as they are. By default, Bind returns the
+	// first error.
+	CollectAllErrors bool
+}
+
+// BodyDecoder decodes request body for single media type. Register decoders for media types that Echo does not
+// support out of the box (i.e. MIMEApplicationMsgpack, MIMEApplicationProtobuf) with Echo.Decoders or
+// DefaultBinder.Decoders.
+//
+// Media types with structured syntax suffix (`application/merge-patch+json`, `application/vnd.foo+xml`) that have no
+// decoder registered for them are decoded with the decoder for their suffix media type (`application/json`,
+// `application/xml`).
+type BodyDecoder interface {
+	// Decode decodes the request body to target. Errors that are not HTTPError are returned to the client as
+	// bad request errors.
+	Decode(c *Context, target any) error
+}
+
+// BodyDecoderFunc is an adapter to allow the use of ordinary functions as BodyDecoder.
+type BodyDecoderFunc func(c *Context, target any) error
+
+// Decode calls f(c, target).
+func (f BodyDecoderFunc) Decode(c *Context, target any) error {
+	return f(c, target)
+}
 
 // BindUnmarshaler is the interface used to wrap the UnmarshalParam method.
 // Types that don't implement this, but do implement encoding.TextUnmarshaler
@@ -42,30 +71,42 @@ type bindMultipleUnmarshaler interface {
 
 // BindPathValues binds path parameter values to bindable object
 func BindPathValues(c *Context, target any) error {
+	if err := bindData(target, pathValuesData(c), "param", nil); err != nil {
+		return wrapBindDataError(err)
+	}
+	return nil
+}
+
+func pathValuesData(c *Context) map[string][]string {
 	params := map[string][]string{}
 	for _, param := range c.PathValues() {
 		params[param.Name] = []string{param.Value}
 	}
-	if err := bindData(target, params, "param", nil); err != nil {
-		return ErrBadRequest.Wrap(err)
-	}
-	return nil
+	return params
 }
 
 // BindQueryParams binds query params to bindable object
 func BindQueryParams(c *Context, target any) error {
 	if err := bindData(target, c.QueryParams(), "query", nil); err != nil {
-		return ErrBadRequest.Wrap(err)
+		return wrapBindDataError(err)
 	}
 	return nil
 }
 
-// BindBody binds request body contents to bindable object
+// BindBody binds request body contents to bindable object. Body is decoded with decoder registered for request
+// media type in Echo.Decoders or with built-in decoding for JSON, XML and forms. Other media types result
+// http.StatusUnsupportedMediaType error.
 // NB: then binding forms take note that this implementation uses standard library form parsing
 // which parses form data from BOTH URL and BODY if content type is not MIMEMultipartForm
 // See non-MIMEMultipartForm: https://golang.org/pkg/net/http/#Request.ParseForm
 // See MIMEMultipartForm: https://golang.org/pkg/net/http/#Request.ParseMultipartForm
 func BindBody(c *Context, target any) (err error) {
+	return bindBody(c, target, nil, false)
+}
+
+// bindBody binds request body with decoder from decoders, Echo.Decoders or with built-in decoding. When collectErrors
+// is true form fields that fail to bind are returned as BindingErrors.
+func bindBody(c *Context, target any, decoders map[string]BodyDecoder, collectErrors bool) (err error) {
 	req := c.Request()
 	if req.ContentLength == 0 {
 		return
@@ -73,13 +114,37 @@ func BindBody(c *Context, target any) (err error) {
 
 	// mediatype is found like `mime.ParseMediaType()` does it
 	base, _, _ := strings.Cut(req.Header.Get(HeaderContentType), ";")
-	mediatype := strings.TrimSpace(base)
+	mediatype := strings.ToLower(strings.TrimSpace(base))
+
+	var echoDecoders map[string]BodyDecoder
+	if c.echo != nil {
+		echoDecoders = c.echo.Decoders
+	}
+	decoder := findBodyDecoder(mediatype, decoders, echoDecoders)
+	if decoder == nil {
+		if suffixType := suffixMediaType(mediatype); suffixType != "" {
+			decoder = findBodyDecoder(suffixType, decoders, echoDecoders)
+			mediatype = suffixType
+		}
+	}
+	if decoder != nil {
+		if err = decoder.Decode(c, target); err != nil {
+			var hErr *HTTPError
+			var bErr *BindingError
+			if errors.As(err, &hErr) || errors.As(err, &bErr) {
+				return err
+			}
+			return ErrBadRequest.Wrap(err)
+		}
+		return nil
+	}
 
 	switch mediatype {
 	case MIMEApplicationJSON:
-		if err = c.Echo().JSONSerializer.Deserialize(c, target); err != nil {
+		if err = c.jsonSerializer().Deserialize(c, target); err != nil {
 			var hErr *HTTPError
-			if errors.As(err, &hErr) {
+			var bErr *BindingError
+			if errors.As(err, &hErr) || errors.As(err, &bErr) {
 				return err
 			}
 			return ErrBadRequest.Wrap(err)
@@ -93,27 +158,72 @@ func BindBody(c *Context, target any) (err error) {
 		if err != nil {
 			return ErrBadRequest.Wrap(err)
 		}
-		if err = bindData(target, params, "form", nil); err != nil {
-			return ErrBadRequest.Wrap(err)
-		}
+		return bindFormData(target, params, nil, collectErrors)
 	case MIMEMultipartForm:
 		params, err := c.MultipartForm()
 		if err != nil {
 			return ErrBadRequest.Wrap(err)
 		}
-		if err = bindData(target, params.Value, "form", params.File); err != nil {
-			return ErrBadRequest.Wrap(err)
-		}
+		return bindFormData(target, params.Value, params.File, collectErrors)
 	default:
 		return &HTTPError{Code: http.StatusUnsupportedMediaType}
 	}
 	return nil
 }
 
+func bindFormData(target any, values map[string][]string, files map[string][]*multipart.FileHeader, collectErrors bool) error {
+	b := dataBinder{tag: "form", collectErrors: collectErrors}
+	if err := b.bind(target, values, files, "", 0); err != nil {
+		return wrapBindDataError(err)
+	}
+	if len(b.errors) > 0 {
+		return NewBindingErrors(b.errors)
+	}
+	return nil
+}
+
+// wrapBindDataError wraps bindData error as bad request error. BindingError (nested field errors) is returned as is
+// as it is already bad request error. Invalid `default` tag errors are not client errors and are returned as is.
+func wrapBindDataError(err error) error {
+	var bErr *BindingError
+	if errors.As(err, &bErr) || errors.Is(err, ErrInvalidDefaultTag) {
+		return err
+	}
+	return ErrBadRequest.Wrap(err)
+}
+
+func findBodyDecoder(mediatype string, registries ...map[string]BodyDecoder) BodyDecoder {
+	for _, decoders := range registries {
+		if d, ok := decoders[mediatype]; ok && d != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+// suffixMediaType returns media type for structured syntax suffix of the media type (`application/json` for
+// `application/vnd.foo+json`). Returns empty string when media type has no suffix.
+func suffixMediaType(mediatype string) string {
+	slash := strings.IndexByte(mediatype, '/')
+	plus := strings.LastIndexByte(mediatype, '+')
+	if slash == -1 || plus < slash || plus == len(mediatype)-1 {
+		return ""
+	}
+	return "application/" + mediatype[plus+1:]
+}
+
 // BindHeaders binds HTTP headers to a bindable object
 func BindHeaders(c *Context, target any) error {
 	if err := bindData(target, c.Request().Header, "header", nil); err != nil {
-		return ErrBadRequest.Wrap(err)
+		return wrapBindDataError(err)
+	}
+	return nil
+}
+
+// BindCookies binds request cookies to bindable object. Fields are bound by the `cookie` tag.
+func BindCookies(c *Context, target any) error {
+	if err := bindData(target, cookiesData(c), "cookie", nil); err != nil {
+		return wrapBindDataError(err)
 	}
 	return nil
 }
@@ -121,20 +231,100 @@ func BindHeaders(c *Context, target any) error {
 // Bind implements the `Binder#Bind` function.
 // Binding is done in following order: 1) path params; 2) query params; 3) request body. Each step COULD override previous
 // step bound values. For single source binding use their own methods BindBody, BindQueryParams, BindPathValues.
+// Headers and cookies are not bound, use BindHeaders, BindCookies or Bind with BindOpts.Sources for them.
 func (b *DefaultBinder) Bind(c *Context, target any) error {
-	if err := BindPathValues(c, target); err != nil {
-		return err
-	}
-	// Only bind query parameters for GET/DELETE/HEAD/QUERY to avoid unexpected behavior with destination struct binding from body.
-	// For example a request URL `&id=1&lang=en` with body `{"id":100,"lang":"de"}` would lead to precedence issues.
-	// The HTTP method check restores pre-v4.1.11 behavior to avoid these problems (see issue #1670)
-	method := c.Request().Method
+	return bindSources(c, target, defaultBindSources(c.Request().Method), b.Decoders, b.CollectAllErrors)
+}
+
+// BindSource is a set of request data sources that are bound to struct fields.
+type BindSource uint8
+
+// Request data sources that can be combined with bitwise OR (`echo.BindSourcePath | echo.BindSourceBody`).
+const (
+	// BindSourcePath binds path values to fields with `param` tag.
+	BindSourcePath BindSource = 1 << iota
+	// BindSourceQuery binds query params to fields with `query` tag.
+	BindSourceQuery
+	// BindSourceHeader binds request headers to fields with `header` tag.
+	BindSourceHeader
+	// BindSourceCookie binds request cookies to fields with `cookie` tag.
+	BindSourceCookie
+	// BindSourceBody binds request body (see BindBody).
+	BindSourceBody
+)
+
+// defaultBindSources returns sources that DefaultBinder binds for request method.
+// Only bind query parameters for GET/DELETE/HEAD/QUERY to avoid unexpected behavior with destination struct binding from body.
+// For example a request URL `&id=1&lang=en` with body `{"id":100,"lang":"de"}` would lead to precedence issues.
+// The HTTP method check restores pre-v4.1.11 behavior to avoid these problems (see issue #1670)
+func defaultBindSources(method string) BindSource {
 	if method == http.MethodGet || method == http.MethodDelete || method == http.MethodHead || method == QUERY {
-		if err := BindQueryParams(c, target); err != nil {
+		return BindSourcePath | BindSourceQuery | BindSourceBody
+	}
+	return BindSourcePath | BindSourceBody
+}
+
+// bindSources binds request data from sources to target in order: path values, query params, headers, cookies and
+// body. Each step could override values bound by previous steps. When collectErrors is true binding does not stop at
+// the first field that fails to bind and errors of all failed fields are returned as BindingErrors.
+func bindSources(c *Context, target any, sources BindSource, decoders map[string]BodyDecoder, collectErrors bool) error {
+	var errs []*BindingError
+	bindSource := func(tag string, data map[string][]string) error {
+		b := dataBinder{tag: tag, collectErrors: collectErrors}
+		if err := b.bind(target, data, nil, "", 0); err != nil {
+			return wrapBindDataError(err)
+		}
+		errs = append(errs, b.errors...)
+		return nil
+	}
+	if sources&BindSourcePath != 0 {
+		if err := bindSource("param", pathValuesData(c)); err != nil {
+			return err
+		}
+	}
+	if sources&BindSourceQuery != 0 {
+		if err := bindSource("query", c.QueryParams()); err != nil {
+			return err
+		}
+	}
+	if sources&BindSourceHeader != 0 {
+		if err := bindSource("header", c.Request().Header); err != nil {
 			return err
 		}
 	}
-	return BindBody(c, target)
+	if sources&BindSourceCookie != 0 {
+		if err := bindSource("cookie", cookiesData(c)); err != nil {
+			return err
+		}
+	}
+	if sources&BindSourceBody != 0 {
+		if err := bindBody(c, target, decoders, collectErrors); err != nil {
+			var bErrs *BindingErrors
+			var bErr *BindingError
+			switch {
+			case !collectErrors:
+				return err
+			case errors.As(err, &bErrs):
+				errs = append(errs, bErrs.Errors...)
+			case errors.As(err, &bErr):
+				errs = append(errs, bErr)
+			default:
+				return err
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return NewBindingErrors(errs)
+	}
+	return nil
+}
+
+func cookiesData(c *Context) map[string][]string {
+	cookies := map[string][]string{}
+	for _, cookie := range c.Cookies() {
+		cookies[cookie.Name] = append(cookies[cookie.Name], cookie.Value)
+	}
+	return cookies
 }
 
 // bindFieldMeta is the cached, type-level reflection metadata for a single struct field. Reading struct
@@ -149,10 +339,13 @@ type bindFieldMeta struct {
 	fieldKind reflect.Kind
 	anonymous bool   // reflect.StructField.Anonymous
 	formatTag string // value of the `format` struct tag
-	// binding-source tag values. bindData is only ever called with one of these four tags (see the
-	// callers BindPathValues/BindQueryParams/BindBody/BindHeaders). Keep these fields, the four
+	// defaultValues are values of the `default` struct tag that are bound when the field key is absent. Value of the
+	// tag is split by comma for slice fields that do not unmarshal themselves. Nil when field has no `default` tag.
+	defaultValues []string
+	// binding-source tag values. bindData is only ever called with one of these five tags (see the
+	// callers BindPathValues/BindQueryParams/BindBody/BindHeaders and bindSources). Keep these fields, the five
 	// f.Tag.Get(...) lines in bindMetaFor, and the tagName switch in sync if a source is ever added.
-	param, query, form, header string
+	param, query, form, header, cookie string
 }
 
 // tagName returns the field's tag value for the given binding source tag.
@@ -167,6 +360,8 @@ func (m *bindFieldMeta) tagName(tag string) string {
 		return m.form
 	case "header":
 		return m.header
+	case "cookie":
+		return m.cookie
 	default:
 		return ""
 	}
@@ -175,6 +370,11 @@ func (m *bindFieldMeta) tagName(tag string) string {
 // bindStructMeta is the cached field metadata for a whole struct type, in declaration order.
 type bindStructMeta struct {
 	fields []bindFieldMeta
+	// hasDefaults is true when struct or its untagged struct fields have fields with `default` tag.
+	hasDefaults bool
+	// defaultErr is error for `default` tag value that can not be bound to its field. It is returned every time the
+	// struct is bound as it is a programming error.
+	defaultErr error
 }
 
 // bindStructCache memoizes bindStructMeta keyed by struct reflect.Type. Concurrent double-computation is
@@ -198,20 +398,67 @@ func bindMetaFor(typ reflect.Type) *bindStructMeta {
 			query:     f.Tag.Get("query"),
 			form:      f.Tag.Get("form"),
 			header:    f.Tag.Get("header"),
+			cookie:    f.Tag.Get("cookie"),
+		}
+		fm := &meta.fields[i]
+		if defaultValue, ok := f.Tag.Lookup("default"); ok && !f.Anonymous {
+			fm.defaultValues = defaultTagValues(f.Type, defaultValue)
+			meta.hasDefaults = true
+			// defaults are bound once to check that they are valid
+			if err := setFieldValues(fm.fieldKind, reflect.New(f.Type).Elem(), fm.defaultValues, fm.formatTag); err != nil && meta.defaultErr == nil {
+				meta.defaultErr = fmt.Errorf("%w: field %s: %w", ErrInvalidDefaultTag, f.Name, err)
+			}
+		} else if f.Type.Kind() == reflect.Struct && fm.param == "" && fm.query == "" && fm.form == "" && fm.header == "" && fm.cookie == "" {
+			if sm := bindMetaFor(f.Type); sm.hasDefaults {
+				meta.hasDefaults = true
+			}
 		}
 	}
 	bindStructCache.Store(typ, meta)
 	return meta
 }
 
+// defaultTagValues returns values of the `default` struct tag for field type. Value is split by comma for slices
+// that do not implement BindUnmarshaler or encoding.TextUnmarshaler.
+func defaultTagValues(typ reflect.Type, value string) []string {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	ptrTyp := reflect.PointerTo(typ)
+	if typ.Kind() == reflect.Slice && !ptrTyp.Implements(bindUnmarshalerType) && !ptrTyp.Implements(textUnmarshalerType) {
+		return strings.Split(value, ",")
+	}
+	return []string{value}
+}
+
 // bindData will bind data ONLY fields in destination struct that have EXPLICIT tag
 func bindData(destination any, data map[string][]string, tag string, dataFiles map[string][]*multipart.FileHeader) error {
-	if destination == nil || (len(data) == 0 && len(dataFiles) == 0) {
+	b := dataBinder{tag: tag}
+	return b.bind(destination, data, dataFiles, "", 0)
+}
+
+// dataBinder binds values of single request data source (path values, query params, headers or form) to struct
+// fields that have explicit tag for the source.
+type dataBinder struct {
+	tag string
+	// collectErrors makes binder to continue binding after field fails to bind. Errors of failed fields are collected
+	// to errors.
+	collectErrors bool
+	errors        []*BindingError
+}
+
+// bind binds data to destination that is nested under path (`user[address]`) in the request data. Keys in data are
+// relative to the path. Path is empty and depth is 0 for top level destination.
+func (b *dataBinder) bind(destination any, data map[string][]string, dataFiles map[string][]*multipart.FileHeader, path string, depth int) error {
+	if destination == nil {
 		return nil
 	}
-	hasFiles := len(dataFiles) > 0
 	typ := reflect.TypeOf(destination).Elem()
 	val := reflect.ValueOf(destination).Elem()
+	if len(data) == 0 && len(dataFiles) == 0 && (typ.Kind() != reflect.Struct || !bindMetaFor(typ).hasDefaults) {
+		return nil
+	}
+	hasFiles := len(dataFiles) > 0
 
 	// Support binding to limited Map destinations:
 	// - map[string][]string,
@@ -246,14 +493,20 @@ func bindData(destination any, data map[string][]string, tag string, dataFiles m
 
 	// !struct
 	if typ.Kind() != reflect.Struct {
-		if tag == "param" || tag == "query" || tag == "header" {
+		if b.tag == "param" || b.tag == "query" || b.tag == "header" {
 			// incompatible type, data is probably to be found in the body
 			return nil
 		}
 		return errors.New("binding element must be a struct")
 	}
 
+	// query and form keys can address nested structs, slices and maps with bracket (`user[address][city]`) or dot
+	// (`user.address.city`) notation
+	hasNestedKeys := (b.tag == "query" || b.tag == "form") && hasNestedDataKeys(data)
 	meta := bindMetaFor(typ)
+	if meta.defaultErr != nil {
+		return meta.defaultErr
+	}
 	for fi := range meta.fields { // iterate over all destination fields
 		fm := &meta.fields[fi]
 		structField := val.Field(fm.index)
@@ -266,7 +519,7 @@ func bindData(destination any, data map[string][]string, tag string, dataFiles m
 			continue
 		}
 		structFieldKind := structField.Kind()
-		inputFieldName := fm.tagName(tag)
+		inputFieldName := fm.tagName(b.tag)
 		if fm.anonymous && structFieldKind == reflect.Struct && inputFieldName != "" {
 			// if anonymous struct with query/param/form tags, report an error
 			return errors.New("query/param/form tags are not allowed with anonymous struct field")
@@ -276,7 +529,7 @@ func bindData(destination any, data map[string][]string, tag string, dataFiles m
 			// If tag is nil, we inspect if the field is a not BindUnmarshaler struct and try to bind data into it (might contain fields with tags).
 			// structs that implement BindUnmarshaler are bound only when they have explicit tag
 			if _, ok := structField.Addr().Interface().(BindUnmarshaler); !ok && structFieldKind == reflect.Struct {
-				if err := bindData(structField.Addr().Interface(), data, tag, dataFiles); err != nil {
+				if err := b.bind(structField.Addr().Interface(), data, dataFiles, path, depth); err != nil {
 					return err
 				}
 			}
@@ -310,51 +563,98 @@ func bindData(destination any, data map[string][]string, tag string, dataFiles m
 		}
 
 		if !exists {
-			continue
-		}
-
-		// NOTE: algorithm here is not particularly sophisticated. It probably does not work with absurd types like `**[]*int`
-		// but it is smart enough to handle niche cases like `*int`,`*[]string`,`[]*int` .
-
-		// try unmarshalling first, in case we're dealing with an alias to an array type
-		if ok, err := unmarshalInputsToField(fm.fieldKind, inputValue, structField); ok {
-			if err != nil {
-				return fmt.Errorf("%s: %w", inputFieldName, err)
+			if hasNestedKeys {
+				if err := b.bindNestedField(structField, inputFieldName, data, path, depth); err != nil {
+					if err = b.collect(err); err != nil {
+						return err
+					}
+				}
+			}
+			// default is not bound over value that was set by previous binding step (i.e. path value) or by the caller
+			if fm.defaultValues != nil && structField.IsZero() {
+				if err := setFieldValues(fm.fieldKind, structField, fm.defaultValues, fm.formatTag); err != nil {
+					if err = b.collect(b.fieldError(path, inputFieldName, fm.defaultValues, err)); err != nil {
+						return err
+					}
+				}
 			}
 			continue
 		}
 
-		if ok, err := unmarshalInputToField(fm.fieldKind, inputValue[0], structField, fm.formatTag); ok {
-			if err != nil {
-				return fmt.Errorf("%s: %w", inputFieldName, err)
+		if err := setFieldValues(fm.fieldKind, structField, inputValue, fm.formatTag); err != nil {
+			if err = b.collect(b.fieldError(path, inputFieldName, inputValue, err)); err != nil {
+				return err
 			}
-			continue
 		}
+	}
+	return nil
+}
 
-		// we could be dealing with pointer to slice `*[]string` so dereference it. There are weird OpenAPI generators
-		// that could create struct fields like that.
-		if structFieldKind == reflect.Pointer {
-			structFieldKind = structField.Elem().Kind()
-			structField = structField.Elem()
-		}
+// setFieldValues sets input values to the field. fieldKind is the declared kind of the field.
+func setFieldValues(fieldKind reflect.Kind, field reflect.Value, values []string, formatTag string) error {
+	// NOTE: algorithm here is not particularly sophisticated. It probably does not work with absurd types like `**[]*int`
+	// but it is smart enough to handle niche cases like `*int`,`*[]string`,`[]*int` .
 
-		if structFieldKind == reflect.Slice {
-			sliceOf := structField.Type().Elem().Kind()
-			numElems := len(inputValue)
-			slice := reflect.MakeSlice(structField.Type(), numElems, numElems)
-			for j := range numElems {
-				if err := setWithProperType(sliceOf, inputValue[j], slice.Index(j)); err != nil {
-					return fmt.Errorf("%s: %w", inputFieldName, err)
-				}
+	// try unmarshalling first, in case we're dealing with an alias to an array type
+	if ok, err := unmarshalInputsToField(fieldKind, values, field); ok {
+		return err
+	}
+
+	if ok, err := unmarshalInputToField(fieldKind, values[0], field, formatTag); ok {
+		return err
+	}
+
+	// we could be dealing with pointer to slice `*[]string` so dereference it. There are weird OpenAPI generators
+	// that could create struct fields like that.
+	kind := field.Kind()
+	if kind == reflect.Pointer {
+		kind = field.Elem().Kind()
+		field = field.Elem()
+	}
+
+	if kind == reflect.Slice {
+		sliceOf := field.Type().Elem().Kind()
+		numElems := len(values)
+		slice := reflect.MakeSlice(field.Type(), numElems, numElems)
+		for j := range numElems {
+			if err := setWithProperType(sliceOf, values[j], slice.Index(j)); err != nil {
+				return err
 			}
-			structField.Set(slice)
-			continue
 		}
+		field.Set(slice)
+		return nil
+	}
 
-		if err := setWithProperType(structFieldKind, inputValue[0], structField); err != nil {
-			return fmt.Errorf("%s: %w", inputFieldName, err)
-		}
+	return setWithProperType(kind, values[0], field)
+}
+
+// fieldError creates error for field that failed to bind. Errors for nested fields and errors that are collected are
+// reported as BindingError with the full key path of the field (`user[address][zip]`).
+func (b *dataBinder) fieldError(path string, name string, values []string, err error) error {
+	if path == "" && !b.collectErrors {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return b.bindingError(nestedKeyPath(path, name), values, "failed to bind field value", err)
+}
+
+// bindingError creates BindingError for field with key path in the binder data source.
+func (b *dataBinder) bindingError(path string, values []string, message string, err error) error {
+	return &BindingError{
+		Field:     path,
+		Source:    b.tag,
+		Values:    values,
+		HTTPError: &HTTPError{Code: http.StatusBadRequest, Message: message, err: err},
+	}
+}
+
+// collect adds field error to collected errors and returns nil when binder collects errors. Otherwise, or when err is
+// not a field error, err is returned.
+func (b *dataBinder) collect(err error) error {
+	var bErr *BindingError
+	if !b.collectErrors || !errors.As(err, &bErr) {
+		return err
 	}
+	b.errors = append(b.errors, bErr)
 	return nil
 }
 
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"encoding"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Query and form keys can address fields of nested structs, elements of slices and entries of maps with bracket
+// notation (`user[address][city]`, `items[0][sku]`, OpenAPI `deepObject` style `filter[status]`), dot notation
+// (`user.address.city`, `items.0.sku`) or mix of both. Empty brackets (`ids[]=1&ids[]=2`) append values to slice.
+const (
+	// bindMaxNestingDepth limits how deep nested keys are bound into structs, slices and maps.
+	bindMaxNestingDepth = 10
+	// bindMaxSliceIndex limits slice index in keys (`items[1000][sku]`) as slice is allocated up to the index.
+	bindMaxSliceIndex = 1000
+)
+
+var (
+	bindUnmarshalerType = reflect.TypeFor[BindUnmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+func hasNestedDataKeys(data map[string][]string) bool {
+	for k := range data {
+		if strings.ContainsAny(k, "[.") {
+			return true
+		}
+	}
+	return false
+}
+
+// nestedData returns values of keys nested under name (`name[key]...` or `name.key...`) with name removed from keys
+// (`key...`). Returns nil when there are no nested keys.
+func nestedData(data map[string][]string, name string) map[string][]string {
+	var result map[string][]string
+	for k, v := range data {
+		if len(k) <= len(name) || !strings.HasPrefix(k, name) {
+			continue
+		}
+		rest := k[len(name):]
+		var key string
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				continue
+			}
+			key = rest[1:end] + rest[end+1:]
+		case '.':
+			key = rest[1:]
+		default:
+			continue
+		}
+		if result == nil {
+			result = make(map[string][]string)
+		}
+		result[key] = append(result[key], v...)
+	}
+	return result
+}
+
+// nestedKeySegment returns the first segment of nested key (`0` for `0[sku]` or `0.sku`).
+func nestedKeySegment(key string) string {
+	if i := strings.IndexAny(key, "[."); i != -1 {
+		return key[:i]
+	}
+	return key
+}
+
+// nestedKeyPath returns key path of name nested under path in bracket notation.
+func nestedKeyPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "[" + name + "]"
+}
+
+// bindNestedField binds keys nested under name to struct field (struct, slice or map) that has no value for its name.
+func (b *dataBinder) bindNestedField(field reflect.Value, name string, data map[string][]string, path string, depth int) error {
+	nested := nestedData(data, name)
+	if len(nested) == 0 {
+		return nil
+	}
+	return b.bindNestedValue(field, nestedKeyPath(path, name), nested, depth+1)
+}
+
+func (b *dataBinder) bindNestedValue(field reflect.Value, path string, data map[string][]string, depth int) error {
+	typ := field.Type()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		ptrTyp := reflect.PointerTo(typ)
+		if ptrTyp.Implements(bindUnmarshalerType) || ptrTyp.Implements(textUnmarshalerType) {
+			return nil // types like time.Time are bound only from single value
+		}
+	case reflect.Slice:
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if depth > bindMaxNestingDepth {
+		return b.bindingError(path, nil, "nesting depth limit exceeded", nil)
+	}
+
+	for field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		field = field.Elem()
+	}
+	switch field.Kind() {
+	case reflect.Slice:
+		return b.bindNestedSlice(field, path, data, depth)
+	case reflect.Map:
+		return b.bindNestedMap(field, path, data, depth)
+	default:
+		return b.bind(field.Addr().Interface(), data, nil, path, depth)
+	}
+}
+
+// bindNestedElement binds values of segment and keys nested under segment to slice element or map value.
+func (b *dataBinder) bindNestedElement(elem reflect.Value, segment string, data map[string][]string, path string, depth int) error {
+	if values, ok := data[segment]; ok && len(values) > 0 {
+		if elem.Kind() == reflect.Interface {
+			elem.Set(reflect.ValueOf(values[0]))
+		} else if err := setFieldValues(elem.Kind(), elem, values, ""); err != nil {
+			return b.bindingError(nestedKeyPath(path, segment), values, "failed to bind field value", err)
+		}
+	}
+	return b.bindNestedField(elem, segment, data, path, depth)
+}
+
+func (b *dataBinder) bindNestedSlice(field reflect.Value, path string, data map[string][]string, depth int) error {
+	indexes := map[int]string{}
+	maxIndex := -1
+	for key := range data {
+		segment := nestedKeySegment(key)
+		if segment == "" {
+			continue // empty brackets are appended after indexed elements
+		}
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 {
+			return b.bindingError(nestedKeyPath(path, segment), data[key], "invalid slice index", err)
+		}
+		if index > bindMaxSliceIndex {
+			return b.bindingError(nestedKeyPath(path, segment), data[key], "slice index limit exceeded", nil)
+		}
+		indexes[index] = segment
+		maxIndex = max(maxIndex, index)
+	}
+	appendValues := data[""]
+
+	slice := field
+	if n := maxIndex + 1; n > field.Len() {
+		slice = reflect.MakeSlice(field.Type(), n, n)
+		reflect.Copy(slice, field)
+	}
+	for _, index := range slices.Sorted(maps.Keys(indexes)) {
+		if err := b.bindNestedElement(slice.Index(index), indexes[index], data, path, depth); err != nil {
+			if err = b.collect(err); err != nil {
+				return err
+			}
+		}
+	}
+	for _, value := range appendValues {
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := setFieldValues(elem.Kind(), elem, []string{value}, ""); err != nil {
+			if err = b.collect(b.bindingError(path+"[]", []string{value}, "failed to bind field value", err)); err != nil {
+				return err
+			}
+			continue
+		}
+		slice = reflect.Append(slice, elem)
+	}
+	field.Set(slice)
+	return nil
+}
+
+func (b *dataBinder) bindNestedMap(field reflect.Value, path string, data map[string][]string, depth int) error {
+	segments := map[string]struct{}{}
+	for key := range data {
+		if segment := nestedKeySegment(key); segment != "" {
+			segments[segment] = struct{}{}
+		}
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+	if field.IsNil() {
+		field.Set(reflect.MakeMap(field.Type()))
+	}
+	elemType := field.Type().Elem()
+	for _, segment := range slices.Sorted(maps.Keys(segments)) {
+		key := reflect.ValueOf(segment).Convert(field.Type().Key())
+		elem := reflect.New(elemType).Elem()
+		if existing := field.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := b.bindNestedElement(elem, segment, data, path, depth); err != nil {
+			if err = b.collect(err); err != nil {
+				return err
+			}
+			continue
+		}
+		field.SetMapIndex(key, elem)
+	}
+	return nil
+}
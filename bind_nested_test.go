@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nestedBindAddress struct {
+	City string `query:"city" form:"city"`
+	Zip  int    `query:"zip" form:"zip"`
+}
+
+type nestedBindItem struct {
+	SKU string `query:"sku" form:"sku"`
+	Qty int    `query:"qty" form:"qty"`
+}
+
+type nestedBindTarget struct {
+	Name string `query:"name" form:"name"`
+	User struct {
+		Name    string             `query:"name" form:"name"`
+		Address *nestedBindAddress `query:"address" form:"address"`
+	} `query:"user" form:"user"`
+	Items  []nestedBindItem              `query:"items" form:"items"`
+	IDs    []int                         `query:"ids" form:"ids"`
+	Attrs  map[string]string             `query:"attrs" form:"attrs"`
+	Places map[string]*nestedBindAddress `query:"places" form:"places"`
+	Filter struct {
+		Status string    `query:"status"`
+		Since  time.Time `query:"since" format:"2006-01-02"`
+	} `query:"filter"`
+	Dotted string `query:"dotted.name"`
+}
+
+func TestBindQueryParams_nested(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		whenURL     string
+		expect      func(t *testing.T, target nestedBindTarget)
+		expectError string
+		expectField string
+	}{
+		{
+			name:    "ok, bracket notation for nested structs",
+			whenURL: "/?user[name]=Jon&user[address][city]=Tallinn&user[address][zip]=10115&name=top",
+			expect: func(t *testing.T, target nestedBindTarget) {
+				assert.Equal(t, "top", target.Name)
+				assert.Equal(t, "Jon", target.User.Name)
+				assert.Equal(t, &nestedBindAddress{City: "Tallinn", Zip: 10115}, target.User.Address)
+			},
+		},
+		{
+			name:    "ok, dot notation for nested structs",
+			whenURL: "/?user.name=Jon&user.address.city=Tallinn&user[address].zip=1",
+			expect: func(t *testing.T, target nestedBindTarget) {
+				assert.Equal(t, "Jon", target.User.Name)
+				assert.Equal(t, &nestedBindAddress{City: "Tallinn", Zip: 1}, target.User.Address)
+			},
+		},
+		{
+			name:    "ok, indexed slice of structs",
+			whenURL: "/?items[1][sku]=B&items[0][sku]=A&items[0][qty]=2&items.2.sku=C",
+			expect: func(t *testing.T, target nestedBindTarget) {
+				assert.Equal(t, []nestedBindItem{{SKU: "A", Qty: 2}, {SKU: "B"}, {SKU: "C"}}, target.Items)
+			},
+		},
+		{
+			name:    "ok, indexed and appended slice of scalars",
+			whenURL: "/?ids[1]=20&ids[0]=10&ids[]=30",
+			expect: func(t *testing.T, target nestedBindTarget) {
+				assert.Equal(t, []int{10, 20, 30}, target.IDs)
+			},
+		},
+		{
+			name:    "ok, maps of scalars and structs",
+			whenURL: "/?attrs[color]=red&attrs.size=XL&places[home][city]=Tartu&places[work][zip]=5",
+			expect: func(t *testing.T, target nestedBindTarget) {
+				assert.Equal(t, map[string]string{"color": "red", "size": "XL"}, target.Attrs)
+				assert.Equal(t, map[string]*nestedBindAddress{
+					"home": {City: "Tartu"},
+					"work": {Zip: 5},
+				}, target.Places)
+			},
+		},
+		{
+			name:    "ok, deepObject style with format tag",
+			whenURL: "/?filter[status]=open&filter[since]=2024-05-01",
+			expect: func(t *testing.T, target nestedBindTarget) {
+				assert.Equal(t, "open", target.Filter.Status)
+				assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), target.Filter.Since)
+			},
+		},
+		{
+			name:    "ok, flat key with dot has priority",
+			whenURL: "/?dotted.name=x",
+			expect: func(t *testing.T, target nestedBindTarget) {
+				assert.Equal(t, "x", target.Dotted)
+			},
+		},
+		{
+			name:        "nok, error reports full key path",
+			whenURL:     "/?items[3][qty]=nope",
+			expectError: `code=400, message=failed to bind field value, err=strconv.ParseInt: parsing "nope": invalid syntax, field=items[3][qty]`,
+			expectField: "items[3][qty]",
+		},
+		{
+			name:        "nok, error in nested struct reports full key path",
+			whenURL:     "/?user[address][zip]=x",
+			expectError: `code=400, message=failed to bind field value, err=strconv.ParseInt: parsing "x": invalid syntax, field=user[address][zip]`,
+			expectField: "user[address][zip]",
+		},
+		{
+			name:        "nok, invalid slice index",
+			whenURL:     "/?items[x][sku]=A",
+			expectError: `code=400, message=invalid slice index, err=strconv.Atoi: parsing "x": invalid syntax, field=items[x]`,
+			expectField: "items[x]",
+		},
+		{
+			name:        "nok, slice index limit",
+			whenURL:     "/?items[1001][sku]=A",
+			expectError: `code=400, message=slice index limit exceeded, field=items[1001]`,
+			expectField: "items[1001]",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			target := nestedBindTarget{}
+			err := BindQueryParams(c, &target)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				var bErr *BindingError
+				assert.True(t, errors.As(err, &bErr))
+				assert.Equal(t, tc.expectField, bErr.Field)
+				return
+			}
+			assert.NoError(t, err)
+			tc.expect(t, target)
+		})
+	}
+}
+
+func TestBindQueryParams_nestedDepthLimit(t *testing.T) {
+	type node struct {
+		Value string `query:"value"`
+		Next  *node  `query:"next"`
+	}
+	target := struct {
+		Root node `query:"root"`
+	}{}
+
+	key := "root" + strings.Repeat("[next]", bindMaxNestingDepth) + "[value]"
+	req := httptest.NewRequest(http.MethodGet, "/?"+url.QueryEscape(key)+"=x", nil)
+	c := New().NewContext(req, httptest.NewRecorder())
+
+	err := BindQueryParams(c, &target)
+	assert.EqualError(t, err, "code=400, message=nesting depth limit exceeded, field=root"+strings.Repeat("[next]", bindMaxNestingDepth))
+
+	key = "root" + strings.Repeat("[next]", bindMaxNestingDepth-2) + "[value]"
+	req = httptest.NewRequest(http.MethodGet, "/?"+url.QueryEscape(key)+"=x", nil)
+	c = New().NewContext(req, httptest.NewRecorder())
+
+	assert.NoError(t, BindQueryParams(c, &target))
+	n := target.Root
+	for range bindMaxNestingDepth - 2 {
+		n = *n.Next
+	}
+	assert.Equal(t, "x", n.Value)
+}
+
+func TestBindBody_nestedForm(t *testing.T) {
+	form := url.Values{}
+	form.Set("user[name]", "Jon")
+	form.Set("user[address][city]", "Tallinn")
+	form.Set("items[0][sku]", "A")
+	form.Set("items[1][sku]", "B")
+	form.Set("items[1][qty]", "3")
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set(HeaderContentType, MIMEApplicationForm)
+	c := New().NewContext(req, httptest.NewRecorder())
+
+	target := nestedBindTarget{}
+	assert.NoError(t, BindBody(c, &target))
+	assert.Equal(t, "Jon", target.User.Name)
+	assert.Equal(t, &nestedBindAddress{City: "Tallinn"}, target.User.Address)
+	assert.Equal(t, []nestedBindItem{{SKU: "A"}, {SKU: "B", Qty: 3}}, target.Items)
+}
@@ -1698,3 +1698,390 @@ func TestTimeFormatBinding(t *testing.T) {
 		})
 	}
 }
+
+func TestBind_defaultTag(t *testing.T) {
+	type target struct {
+		ID        int         `param:"id" query:"id" default:"1"`
+		Page      int         `query:"page" form:"page" default:"1"`
+		PerPage   *int        `query:"per_page" form:"per_page" default:"20"`
+		Sort      []string    `query:"sort" form:"sort" default:"name,-created"`
+		Tags      StringArray `query:"tags" default:"a,b"`
+		Since     time.Time   `query:"since" format:"2006-01-02" default:"2024-01-01"`
+		Lang      string      `header:"Accept-Language" default:"en"`
+		NoDefault string      `query:"no_default"`
+	}
+	perPage20 := 20
+
+	var testCases = []struct {
+		name       string
+		whenMethod string
+		whenURL    string
+		whenForm   string
+		expect     target
+	}{
+		{
+			name:       "ok, defaults are used for absent keys",
+			whenMethod: http.MethodGet,
+			whenURL:    "/",
+			expect: target{
+				ID:      1,
+				Page:    1,
+				PerPage: &perPage20,
+				Sort:    []string{"name", "-created"},
+				Tags:    StringArray{"a", "b"},
+				Since:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Lang:    "en",
+			},
+		},
+		{
+			name:       "ok, present keys override defaults",
+			whenMethod: http.MethodGet,
+			whenURL:    "/?id=5&page=3&per_page=&sort=id&tags=x&since=2025-02-03&no_default=z",
+			expect: target{
+				ID:        5,
+				Page:      3,
+				PerPage:   new(int),
+				Sort:      []string{"id"},
+				Tags:      StringArray{"x"},
+				Since:     time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC),
+				Lang:      "en",
+				NoDefault: "z",
+			},
+		},
+		{
+			name:       "ok, defaults with form binding",
+			whenMethod: http.MethodPost,
+			whenURL:    "/",
+			whenForm:   "page=2",
+			expect: target{ // Tags and Since have no form tag
+				ID:      1,
+				Page:    2,
+				PerPage: &perPage20,
+				Sort:    []string{"name", "-created"},
+				Lang:    "en",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			var body io.Reader
+			if tc.whenForm != "" {
+				body = strings.NewReader(tc.whenForm)
+			}
+			req := httptest.NewRequest(tc.whenMethod, tc.whenURL, body)
+			if tc.whenForm != "" {
+				req.Header.Set(HeaderContentType, MIMEApplicationForm)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			result := target{}
+			assert.NoError(t, BindPathValues(c, &result))
+			assert.NoError(t, BindHeaders(c, &result))
+			if tc.whenForm != "" {
+				assert.NoError(t, BindBody(c, &result))
+			} else {
+				assert.NoError(t, BindQueryParams(c, &result))
+			}
+			assert.Equal(t, tc.expect, result)
+		})
+	}
+}
+
+func TestBind_defaultTagDoesNotOverridePathValue(t *testing.T) {
+	type target struct {
+		ID int `param:"id" query:"id" default:"1"`
+	}
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.SetPathValues(PathValues{{Name: "id", Value: "7"}})
+
+	result := target{}
+	assert.NoError(t, c.Bind(&result))
+	assert.Equal(t, 7, result.ID)
+}
+
+func TestBind_invalidDefaultTag(t *testing.T) {
+	type target struct {
+		Page int `query:"page" default:"first"`
+	}
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/?page=2", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	result := target{}
+	err := BindQueryParams(c, &result)
+	assert.ErrorIs(t, err, ErrInvalidDefaultTag)
+	assert.EqualError(t, err, `invalid default struct tag value: field Page: strconv.ParseInt: parsing "first": invalid syntax`)
+	var hErr *HTTPError
+	assert.False(t, errors.As(err, &hErr), "invalid default is not a client error")
+}
+
+func TestBindBody_decoders(t *testing.T) {
+	lineDecoder := BodyDecoderFunc(func(c *Context, target any) error {
+		b, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		id, name, ok := strings.Cut(strings.TrimSpace(string(b)), " ")
+		if !ok {
+			return errors.New("invalid line")
+		}
+		u := target.(*user)
+		u.Name = name
+		u.ID, err = strconv.Atoi(id)
+		return err
+	})
+	teapotDecoder := BodyDecoderFunc(func(c *Context, target any) error {
+		return NewHTTPError(http.StatusTeapot, "teapot")
+	})
+
+	var testCases = []struct {
+		name           string
+		givenEcho      map[string]BodyDecoder
+		givenBinder    map[string]BodyDecoder
+		whenMediaType  string
+		whenBody       string
+		expect         user
+		expectError    string
+		expectHTTPCode int
+	}{
+		{
+			name:          "ok, decoder registered with Echo",
+			givenEcho:     map[string]BodyDecoder{"text/x-user": lineDecoder},
+			whenMediaType: "text/x-user; charset=utf-8",
+			whenBody:      "1 Jon Snow",
+			expect:        user{ID: 1, Name: "Jon Snow"},
+		},
+		{
+			name:          "ok, binder decoder has priority over Echo decoder",
+			givenEcho:     map[string]BodyDecoder{"text/x-user": teapotDecoder},
+			givenBinder:   map[string]BodyDecoder{"text/x-user": lineDecoder},
+			whenMediaType: "Text/X-User",
+			whenBody:      "2 Arya",
+			expect:        user{ID: 2, Name: "Arya"},
+		},
+		{
+			name:          "ok, decoder replaces built-in JSON decoding",
+			givenEcho:     map[string]BodyDecoder{MIMEApplicationJSON: lineDecoder},
+			whenMediaType: MIMEApplicationJSON,
+			whenBody:      "3 Sansa",
+			expect:        user{ID: 3, Name: "Sansa"},
+		},
+		{
+			name:          "ok, structured syntax suffix uses built-in JSON decoding",
+			whenMediaType: "application/merge-patch+json",
+			whenBody:      userJSON,
+			expect:        user{ID: 1, Name: "Jon Snow"},
+		},
+		{
+			name:          "ok, structured syntax suffix uses built-in XML decoding",
+			whenMediaType: "application/vnd.foo+xml",
+			whenBody:      userXML,
+			expect:        user{ID: 1, Name: "Jon Snow"},
+		},
+		{
+			name:          "ok, structured syntax suffix uses registered decoder",
+			givenEcho:     map[string]BodyDecoder{"application/cbor": lineDecoder},
+			whenMediaType: "application/vnd.foo+cbor",
+			whenBody:      "4 Bran",
+			expect:        user{ID: 4, Name: "Bran"},
+		},
+		{
+			name:          "ok, exact media type has priority over suffix",
+			givenEcho:     map[string]BodyDecoder{"application/vnd.foo+json": lineDecoder},
+			whenMediaType: "application/vnd.foo+json",
+			whenBody:      "5 Rickon",
+			expect:        user{ID: 5, Name: "Rickon"},
+		},
+		{
+			name:           "nok, decoder error is bad request",
+			givenEcho:      map[string]BodyDecoder{"text/x-user": lineDecoder},
+			whenMediaType:  "text/x-user",
+			whenBody:       "invalid",
+			expectError:    "code=400, message=Bad Request, err=invalid line",
+			expectHTTPCode: http.StatusBadRequest,
+		},
+		{
+			name:           "nok, decoder HTTPError is returned as is",
+			givenEcho:      map[string]BodyDecoder{"text/x-user": teapotDecoder},
+			whenMediaType:  "text/x-user",
+			whenBody:       "1 Jon",
+			expectError:    "code=418, message=teapot",
+			expectHTTPCode: http.StatusTeapot,
+		},
+		{
+			name:           "nok, media type without decoder",
+			whenMediaType:  MIMEApplicationMsgpack,
+			whenBody:       "x",
+			expectError:    "code=415, message=Unsupported Media Type",
+			expectHTTPCode: http.StatusUnsupportedMediaType,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.Decoders = tc.givenEcho
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.whenBody))
+			req.Header.Set(HeaderContentType, tc.whenMediaType)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			result := user{}
+			err := (&DefaultBinder{Decoders: tc.givenBinder}).Bind(c, &result)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				assert.Equal(t, tc.expectHTTPCode, StatusCode(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, result)
+		})
+	}
+}
+
+func TestDefaultBinder_CollectAllErrors(t *testing.T) {
+	type target struct {
+		ID    int              `param:"id"`
+		Page  int              `query:"page" form:"page"`
+		Valid string           `query:"valid" form:"valid"`
+		Items []nestedBindItem `query:"items" form:"items"`
+		Since time.Time        `query:"since" form:"since" format:"2006-01-02"`
+	}
+	var testCases = []struct {
+		name         string
+		whenMethod   string
+		whenURL      string
+		whenForm     string
+		expectErrors []*BindingError
+		expectJSON   string
+	}{
+		{
+			name:       "ok, collects errors from path values and query",
+			whenMethod: http.MethodGet,
+			whenURL:    "/?page=x&valid=ok&items[0][qty]=a&items[1][qty]=b&since=today",
+			expectErrors: []*BindingError{
+				{Source: "param", Field: "id", Values: []string{"nope"}},
+				{Source: "query", Field: "page", Values: []string{"x"}},
+				{Source: "query", Field: "items[0][qty]", Values: []string{"a"}},
+				{Source: "query", Field: "items[1][qty]", Values: []string{"b"}},
+				{Source: "query", Field: "since", Values: []string{"today"}},
+			},
+			expectJSON: `{"message":"Bad Request","errors":[` +
+				`{"source":"param","field":"id","message":"failed to bind field value"},` +
+				`{"source":"query","field":"page","message":"failed to bind field value"},` +
+				`{"source":"query","field":"items[0][qty]","message":"failed to bind field value"},` +
+				`{"source":"query","field":"items[1][qty]","message":"failed to bind field value"},` +
+				`{"source":"query","field":"since","message":"failed to bind field value"}]}` + "\n",
+		},
+		{
+			name:       "ok, collects errors from path values and form",
+			whenMethod: http.MethodPost,
+			whenURL:    "/",
+			whenForm:   "page=x&valid=ok&items[0][qty]=1",
+			expectErrors: []*BindingError{
+				{Source: "param", Field: "id", Values: []string{"nope"}},
+				{Source: "form", Field: "page", Values: []string{"x"}},
+			},
+			expectJSON: `{"message":"Bad Request","errors":[` +
+				`{"source":"param","field":"id","message":"failed to bind field value"},` +
+				`{"source":"form","field":"page","message":"failed to bind field value"}]}` + "\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.Binder = &DefaultBinder{CollectAllErrors: true}
+
+			var body io.Reader
+			if tc.whenForm != "" {
+				body = strings.NewReader(tc.whenForm)
+			}
+			req := httptest.NewRequest(tc.whenMethod, tc.whenURL, body)
+			if tc.whenForm != "" {
+				req.Header.Set(HeaderContentType, MIMEApplicationForm)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPathValues(PathValues{{Name: "id", Value: "nope"}})
+
+			result := target{}
+			err := c.Bind(&result)
+
+			var bErrs *BindingErrors
+			if !assert.True(t, errors.As(err, &bErrs)) {
+				return
+			}
+			assert.Equal(t, http.StatusBadRequest, bErrs.StatusCode())
+			assert.Len(t, bErrs.Errors, len(tc.expectErrors))
+			for i, expect := range tc.expectErrors {
+				assert.Equal(t, expect.Source, bErrs.Errors[i].Source)
+				assert.Equal(t, expect.Field, bErrs.Errors[i].Field)
+				assert.Equal(t, expect.Values, bErrs.Errors[i].Values)
+			}
+			assert.Equal(t, "ok", result.Valid, "fields after failed fields are bound")
+
+			var bErr *BindingError
+			assert.True(t, errors.As(err, &bErr))
+			assert.Equal(t, "id", bErr.Field)
+
+			DefaultHTTPErrorHandler(false)(c, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, tc.expectJSON, rec.Body.String())
+		})
+	}
+}
+
+func TestDefaultBinder_CollectAllErrors_requestError(t *testing.T) {
+	e := New()
+	e.Binder = &DefaultBinder{CollectAllErrors: true}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":`))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.SetPathValues(PathValues{{Name: "id", Value: "nope"}})
+
+	result := struct {
+		ID int `param:"id" json:"id"`
+	}{}
+	err := c.Bind(&result)
+	assert.EqualError(t, err, "code=400, message=Bad Request, err=unexpected end of JSON input")
+}
+
+func TestBindingErrors_Error(t *testing.T) {
+	err := NewBindingErrors([]*BindingError{
+		{Source: "query", Field: "page", HTTPError: &HTTPError{Code: http.StatusBadRequest, Message: "failed to bind field value"}},
+		{Source: "query", Field: "size", HTTPError: &HTTPError{Code: http.StatusBadRequest, Message: "failed to bind field value"}},
+	})
+	assert.EqualError(t, err, "code=400, message=Bad Request, errors=[code=400, message=failed to bind field value, field=page; code=400, message=failed to bind field value, field=size]")
+}
+
+func TestBindCookies(t *testing.T) {
+	type target struct {
+		Session string   `cookie:"session"`
+		Theme   string   `cookie:"theme" default:"light"`
+		Visits  int      `cookie:"visits"`
+		Tags    []string `cookie:"tag"`
+		Name    string   `query:"name"`
+	}
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/?name=jon", nil)
+	req.Header.Add(HeaderCookie, "session=abc; visits=3; tag=a; tag=b")
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	result := target{}
+	assert.NoError(t, BindCookies(c, &result))
+	assert.Equal(t, target{Session: "abc", Theme: "light", Visits: 3, Tags: []string{"a", "b"}}, result)
+
+	// default Bind does not bind cookies, so clients can not set bound fields with cookies
+	result = target{}
+	assert.NoError(t, c.Bind(&result))
+	assert.Equal(t, target{Name: "jon"}, result)
+
+	result, err := Bind[target](c, BindOpts{Sources: BindSourceQuery | BindSourceCookie})
+	assert.NoError(t, err)
+	assert.Equal(t, target{Session: "abc", Theme: "light", Visits: 3, Tags: []string{"a", "b"}, Name: "jon"}, result)
+
+	req.Header.Set(HeaderCookie, "visits=many")
+	err = BindCookies(c, &target{})
+	assert.EqualError(t, err, `code=400, message=Bad Request, err=visits: strconv.ParseInt: parsing "many": invalid syntax`)
+}
@@ -68,10 +68,14 @@ import (
 // BindingError represents an error that occurred while binding request data.
 //
 // Note: JSON serialization is handled by the MarshalJSON method below, not by the
-// struct tags (which are kept for documentation). MarshalJSON emits {"field","message"}.
+// struct tags (which are kept for documentation). MarshalJSON emits {"source","field","message"}
+// where "source" is omitted when it is empty.
 type BindingError struct {
 	// Field is the field name where value binding failed
 	Field string `json:"field"`
+	// Source is the request data source of the field (`param`, `query`, `header` or `form`). It is set by the
+	// DefaultBinder and is empty for errors created with NewBindingError.
+	Source string `json:"source,omitempty"`
 	*HTTPError
 	// Values of parameter that failed to bind.
 	Values []string `json:"-"`
@@ -101,14 +105,78 @@ func (be *BindingError) MarshalJSON() ([]byte, error) {
 		message = http.StatusText(be.Code)
 	}
 	return json.Marshal(struct {
+		Source  string `json:"source,omitempty"`
 		Field   string `json:"field"`
 		Message string `json:"message"`
 	}{
+		Source:  be.Source,
 		Field:   be.Field,
 		Message: message,
 	})
 }
 
+// BindingErrors is returned by DefaultBinder with CollectAllErrors enabled when one or more fields failed to bind.
+// It contains BindingError for every field that failed to bind. JSON serialization lists errors of all fields
+// (e.g. {"message":"Bad Request","errors":[{"source":"query","field":"id","message":"..."}]}) and RFC 9457 problem
+// details list them in the "errors" extension member.
+type BindingErrors struct {
+	*HTTPError
+	// Errors are errors of fields that failed to bind in the order they were bound.
+	Errors []*BindingError `json:"errors"`
+}
+
+// NewBindingErrors creates new instance of bad request error for fields that failed to bind
+func NewBindingErrors(errs []*BindingError) *BindingErrors {
+	return &BindingErrors{
+		HTTPError: &HTTPError{Code: http.StatusBadRequest},
+		Errors:    errs,
+	}
+}
+
+// Error returns error message
+func (be *BindingErrors) Error() string {
+	messages := make([]string, len(be.Errors))
+	for i, err := range be.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%s, errors=[%s]", be.HTTPError.Error(), strings.Join(messages, "; "))
+}
+
+// Unwrap returns errors of fields that failed to bind.
+func (be *BindingErrors) Unwrap() []error {
+	errs := make([]error, len(be.Errors))
+	for i, err := range be.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// MarshalJSON implements json.Marshaler so that DefaultHTTPErrorHandler responds with list of all
+// fields that failed to bind.
+func (be *BindingErrors) MarshalJSON() ([]byte, error) {
+	message := be.Message
+	if message == "" {
+		message = http.StatusText(be.Code)
+	}
+	return json.Marshal(struct {
+		Message string          `json:"message"`
+		Errors  []*BindingError `json:"errors"`
+	}{
+		Message: message,
+		Errors:  be.Errors,
+	})
+}
+
+// ProblemError implements ProblemErrorer so that ProblemDetailsHTTPErrorHandler responds with list of all
+// fields that failed to bind.
+func (be *BindingErrors) ProblemError() *ProblemError {
+	return &ProblemError{
+		Status: be.Code,
+		Detail: be.Message,
+		Errors: be.Errors,
+	}
+}
+
 // ValueBinder provides utility methods for binding query or path parameter to various Go built-in types
 type ValueBinder struct {
 	// ValueFunc is used to get single parameter (first) value from request
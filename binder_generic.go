@@ -6,7 +6,9 @@ package echo
 import (
 	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"strconv"
 	"time"
 )
@@ -45,6 +47,87 @@ const (
 	TimeLayoutUnixTimeNano  = TimeLayout("UnixTimeNano")  // Unix timestamp in nanoseconds
 )
 
+// BindOpts is options for Bind.
+type BindOpts struct {
+	// Sources are request data sources that are bound. When Sources, Strict and CollectAllErrors are not set request
+	// is bound with Context.Bind (Binder registered to Echo or group). Otherwise, sources are bound in order: path
+	// values, query params, headers, cookies and body.
+	//
+	// Optional. Default value is sources that DefaultBinder binds: path values, query params for GET/DELETE/HEAD/QUERY
+	// requests and body. Headers and cookies are bound only when included in Sources
+	// (`echo.BindSourcePath | echo.BindSourceCookie | echo.BindSourceBody`).
+	Sources BindSource
+
+	// Strict enables strict decoding of JSON request body (see DefaultJSONSerializer.Strict).
+	Strict bool
+
+	// CollectAllErrors makes binding to continue after a field fails to bind and to return BindingErrors with errors
+	// of all fields that failed to bind (see DefaultBinder.CollectAllErrors).
+	CollectAllErrors bool
+
+	// Validate validates bound value with Context.Validate.
+	Validate bool
+}
+
+// Bind binds request data to a new value of type T and optionally validates it. It replaces
+//
+//	var req T
+//	if err := c.Bind(&req); err != nil {
+//		return err
+//	}
+//	if err := c.Validate(&req); err != nil {
+//		return err
+//	}
+//
+// with single call
+//
+//	req, err := echo.Bind[CreateUserRequest](c, echo.BindOpts{Validate: true})
+//
+// Errors of invalid request data are HTTP errors that the error handlers respond with status 400 (BindingError,
+// BindingErrors, ValidationErrors or HTTPError). Validation errors that do not have HTTP status code (i.e. errors of
+// third-party validation libraries) are wrapped with ErrBadRequest.
+func Bind[T any](c *Context, opts ...BindOpts) (T, error) {
+	var opt BindOpts
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	var target T
+	if opt.Sources == 0 && !opt.Strict && !opt.CollectAllErrors {
+		if err := c.Bind(&target); err != nil {
+			return target, err
+		}
+	} else {
+		var decoders map[string]BodyDecoder
+		if b, ok := c.binder().(*DefaultBinder); ok {
+			decoders = b.Decoders
+		}
+		if opt.Strict {
+			strictDecoders := make(map[string]BodyDecoder, len(decoders)+1)
+			maps.Copy(strictDecoders, decoders)
+			strictDecoders[MIMEApplicationJSON] = BodyDecoderFunc(BindJSONStrict)
+			decoders = strictDecoders
+		}
+		sources := opt.Sources
+		if sources == 0 {
+			sources = defaultBindSources(c.Request().Method)
+		}
+		if err := bindSources(c, &target, sources, decoders, opt.CollectAllErrors); err != nil {
+			return target, err
+		}
+	}
+
+	if opt.Validate {
+		if err := c.Validate(&target); err != nil {
+			if StatusCode(err) == 0 && !errors.Is(err, ErrValidatorNotRegistered) {
+				return target, ErrBadRequest.Wrap(err)
+			}
+			return target, err
+		}
+	}
+	return target, nil
+}
+
 // PathParam extracts and parses a path parameter from the context by name.
 // It returns the typed value and an error if binding fails. Returns ErrNonExistentKey if parameter not found.
 //
@@ -313,6 +396,95 @@ func FormValuesOr[T any](c *Context, key string, defaultValue []T, opts ...any)
 	return result, nil
 }
 
+// Header extracts and parses the first value of a request header by name. Header name is case-insensitive.
+// It returns the typed value and an error if binding fails. Returns ErrNonExistentKey if header not found.
+//
+// Empty String Handling:
+//
+//	If the header exists but has an empty value, the zero value of type T is returned with no error.
+//
+// Example:
+//
+//	since, err := echo.Header[time.Time](c, "If-Modified-Since", echo.TimeLayout(http.TimeFormat))
+//
+// See ParseValue for supported types and options
+func Header[T any](c *Context, name string, opts ...any) (T, error) {
+	values := c.Request().Header.Values(name)
+	if len(values) == 0 {
+		var zero T
+		return zero, ErrNonExistentKey
+	}
+	value := values[0]
+	v, err := ParseValue[T](value, opts...)
+	if err != nil {
+		return v, NewBindingError(name, []string{value}, "header", err)
+	}
+	return v, nil
+}
+
+// HeaderOr extracts and parses the first value of a request header by name. Header name is case-insensitive.
+// Returns defaultValue if the header is not found or has an empty value.
+// Returns an error only if parsing fails (e.g., "abc" for int type).
+//
+// Example:
+//
+//	limit, err := echo.HeaderOr[int](c, "X-Rate-Limit", 100)
+//	// If "X-Rate-Limit" is missing: returns (100, nil)
+//	// If "X-Rate-Limit" is "10": returns (10, nil)
+//	// If "X-Rate-Limit" is "abc": returns BindingError
+//
+// See ParseValue for supported types and options
+func HeaderOr[T any](c *Context, name string, defaultValue T, opts ...any) (T, error) {
+	values := c.Request().Header.Values(name)
+	if len(values) == 0 {
+		return defaultValue, nil
+	}
+	value := values[0]
+	v, err := ParseValueOr[T](value, defaultValue, opts...)
+	if err != nil {
+		return v, NewBindingError(name, []string{value}, "header", err)
+	}
+	return v, nil
+}
+
+// Cookie extracts and parses the value of a request cookie by name.
+// It returns the typed value and an error if binding fails. Returns ErrNonExistentKey if cookie not found.
+//
+// Empty String Handling:
+//
+//	If the cookie exists but has an empty value, the zero value of type T is returned with no error.
+//
+// See ParseValue for supported types and options
+func Cookie[T any](c *Context, name string, opts ...any) (T, error) {
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		var zero T
+		return zero, ErrNonExistentKey
+	}
+	v, err := ParseValue[T](cookie.Value, opts...)
+	if err != nil {
+		return v, NewBindingError(name, []string{cookie.Value}, "cookie", err)
+	}
+	return v, nil
+}
+
+// CookieOr extracts and parses the value of a request cookie by name.
+// Returns defaultValue if the cookie is not found or has an empty value.
+// Returns an error only if parsing fails (e.g., "abc" for int type).
+//
+// See ParseValue for supported types and options
+func CookieOr[T any](c *Context, name string, defaultValue T, opts ...any) (T, error) {
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return defaultValue, nil
+	}
+	v, err := ParseValueOr[T](cookie.Value, defaultValue, opts...)
+	if err != nil {
+		return v, NewBindingError(name, []string{cookie.Value}, "cookie", err)
+	}
+	return v, nil
+}
+
 // ParseValues parses value to generic type slice. Same types are supported as ParseValue
 // function but the result type is slice instead of scalar value.
 //
@@ -1614,3 +1614,179 @@ func TestFormValuesOr(t *testing.T) {
 		})
 	}
 }
+
+type bindGenericRequest struct {
+	ID      int    `param:"id" json:"-"`
+	Page    int    `query:"page" json:"-"`
+	TraceID string `header:"X-Trace-Id" json:"-"`
+	Session string `cookie:"session" json:"-"`
+	Name    string `json:"name" validate:"required"`
+}
+
+func TestBind(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenOpts   []BindOpts
+		whenMethod  string
+		whenURL     string
+		whenBody    string
+		expect      bindGenericRequest
+		expectError string
+	}{
+		{
+			name:       "ok, binds like Context.Bind by default, headers and cookies are not bound",
+			whenMethod: http.MethodPost,
+			whenURL:    "/?page=2",
+			whenBody:   `{"name":"Jon"}`,
+			expect:     bindGenericRequest{ID: 7, Name: "Jon"},
+		},
+		{
+			name:       "ok, selected sources",
+			givenOpts:  []BindOpts{{Sources: BindSourceQuery | BindSourceHeader | BindSourceCookie}},
+			whenMethod: http.MethodPost,
+			whenURL:    "/?page=2",
+			whenBody:   `{"name":"Jon"}`,
+			expect:     bindGenericRequest{Page: 2, TraceID: "trace", Session: "abc"},
+		},
+		{
+			name:       "ok, validate",
+			givenOpts:  []BindOpts{{Validate: true}},
+			whenMethod: http.MethodPost,
+			whenURL:    "/",
+			whenBody:   `{"name":"Jon"}`,
+			expect:     bindGenericRequest{ID: 7, Name: "Jon"},
+		},
+		{
+			name:        "nok, validation error",
+			givenOpts:   []BindOpts{{Validate: true}},
+			whenMethod:  http.MethodPost,
+			whenURL:     "/",
+			whenBody:    `{}`,
+			expect:      bindGenericRequest{ID: 7},
+			expectError: "code=400, message=Bad Request, errors=[field=name, rule=required, message=is required]",
+		},
+		{
+			name:        "nok, strict mode rejects unknown field",
+			givenOpts:   []BindOpts{{Strict: true}},
+			whenMethod:  http.MethodPost,
+			whenURL:     "/",
+			whenBody:    `{"name":"Jon","admin":true}`,
+			expect:      bindGenericRequest{ID: 7},
+			expectError: "code=400, message=unknown field, field=/admin",
+		},
+		{
+			name:        "nok, collect all errors",
+			givenOpts:   []BindOpts{{Sources: BindSourceQuery | BindSourceHeader, CollectAllErrors: true}},
+			whenMethod:  http.MethodGet,
+			whenURL:     "/?page=x",
+			expectError: `code=400, message=Bad Request, errors=[code=400, message=failed to bind field value, err=strconv.ParseInt: parsing "x": invalid syntax, field=page]`,
+			expect:      bindGenericRequest{TraceID: "trace"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.Validator = &StructValidator{}
+
+			req := httptest.NewRequest(tc.whenMethod, tc.whenURL, strings.NewReader(tc.whenBody))
+			if tc.whenBody != "" {
+				req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+			}
+			req.Header.Set("X-Trace-Id", "trace")
+			req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+			c := e.NewContext(req, httptest.NewRecorder())
+			c.SetPathValues(PathValues{{Name: "id", Value: "7"}})
+
+			result, err := Bind[bindGenericRequest](c, tc.givenOpts...)
+			assert.Equal(t, tc.expect, result)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				assert.Equal(t, http.StatusBadRequest, StatusCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type bindGenericPlainValidator struct{}
+
+func (bindGenericPlainValidator) Validate(i any) error {
+	return fmt.Errorf("invalid %T", i)
+}
+
+func TestBind_validatorErrorWithoutStatus(t *testing.T) {
+	e := New()
+	e.Validator = bindGenericPlainValidator{}
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	_, err := Bind[bindGenericRequest](c, BindOpts{Validate: true})
+	assert.EqualError(t, err, "code=400, message=Bad Request, err=invalid *echo.bindGenericRequest")
+
+	e.Validator = nil
+	_, err = Bind[bindGenericRequest](c, BindOpts{Validate: true})
+	assert.ErrorIs(t, err, ErrValidatorNotRegistered)
+}
+
+func TestHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Rate-Limit", "10")
+	req.Header.Set("X-Invalid", "abc")
+	req.Header.Set(HeaderIfModifiedSince, "Wed, 21 Oct 2015 07:28:00 GMT")
+	c := New().NewContext(req, httptest.NewRecorder())
+
+	v, err := Header[int](c, "x-rate-limit")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, v)
+
+	since, err := Header[time.Time](c, HeaderIfModifiedSince, TimeLayout(http.TimeFormat))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC), since)
+
+	_, err = Header[int](c, "X-Missing")
+	assert.ErrorIs(t, err, ErrNonExistentKey)
+
+	_, err = Header[int](c, "X-Invalid")
+	assert.EqualError(t, err, `code=400, message=header, err=failed to parse value, err: strconv.ParseInt: parsing "abc": invalid syntax, field=X-Invalid`)
+
+	v, err = HeaderOr[int](c, "X-Missing", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, v)
+
+	v, err = HeaderOr[int](c, "X-Rate-Limit", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, v)
+
+	_, err = HeaderOr[int](c, "X-Invalid", 100)
+	assert.Error(t, err)
+}
+
+func TestCookie(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "visits", Value: "3"})
+	req.AddCookie(&http.Cookie{Name: "invalid", Value: "abc"})
+	req.AddCookie(&http.Cookie{Name: "seen", Value: "1700000000"})
+	c := New().NewContext(req, httptest.NewRecorder())
+
+	v, err := Cookie[int](c, "visits")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	seen, err := Cookie[time.Time](c, "seen", TimeLayoutUnixTime)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), seen)
+
+	_, err = Cookie[int](c, "missing")
+	assert.ErrorIs(t, err, ErrNonExistentKey)
+
+	_, err = Cookie[int](c, "invalid")
+	assert.EqualError(t, err, `code=400, message=cookie, err=failed to parse value, err: strconv.ParseInt: parsing "abc": invalid syntax, field=invalid`)
+
+	v, err = CookieOr[int](c, "missing", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	v, err = CookieOr[int](c, "visits", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+}
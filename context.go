@@ -87,7 +87,11 @@ type Context struct {
 	logger *slog.Logger
 
 	path string
-	lock sync.RWMutex
+	// mountPrefix is the path prefix Echo instance handling the request is mounted under with Echo.Mount.
+	mountPrefix string
+	// group is the Group of the matched route. Group components override Echo components.
+	group *Group
+	lock  sync.RWMutex
 }
 
 // NewContext returns a new Context instance.
@@ -148,6 +152,8 @@ func (c *Context) Reset(r *http.Request, w http.ResponseWriter) {
 	c.logger = c.echo.Logger
 
 	c.route = nil
+	c.group = nil
+	c.mountPrefix = ""
 	c.handler = nil
 	c.dsw = delayedStatusWriter{}
 	c.path = ""
@@ -272,10 +278,14 @@ func (c *Context) SetPath(p string) {
 // * Router did not find matching route - 404 (route not found)
 // * Router did not find matching route with same method - 405 (method not allowed)
 func (c *Context) RouteInfo() RouteInfo {
-	if c.route != nil {
-		return c.route.Clone()
+	if c.route == nil {
+		return RouteInfo{}
 	}
-	return RouteInfo{}
+	ri := c.route.Clone()
+	if c.mountPrefix != "" && ri.Path != "" {
+		ri.Path = c.mountPrefix + ri.Path
+	}
+	return ri
 }
 
 // Param returns path parameter by name.
@@ -311,7 +321,11 @@ func (c *Context) SetPathValues(pathValues PathValues) {
 // InitializeRoute sets the route related variables of this request to the context.
 func (c *Context) InitializeRoute(ri *RouteInfo, pathValues *PathValues) {
 	c.route = ri
+	c.group = ri.group
 	c.path = ri.Path
+	if c.mountPrefix != "" && ri.Path != "" {
+		c.path = c.mountPrefix + ri.Path
+	}
 	c.setPathValues(pathValues)
 }
 
@@ -475,22 +489,24 @@ func (c *Context) Set(key string, val any) {
 // Bind binds path params, query params and the request body into provided type `i`. The default binder
 // binds body based on Content-Type header.
 func (c *Context) Bind(i any) error {
-	return c.echo.Binder.Bind(c, i)
+	return c.binder().Bind(c, i)
 }
 
 // Validate validates provided `i`. It is usually called after `Context#Bind()`.
-// Validator must be registered using `Echo#Validator`.
+// Validator must be registered using `Echo#Validator` or `GroupConfig.Validator`.
 func (c *Context) Validate(i any) error {
-	if c.echo.Validator == nil {
+	validator := c.validator()
+	if validator == nil {
 		return ErrValidatorNotRegistered
 	}
-	return c.echo.Validator.Validate(i)
+	return validator.Validate(i)
 }
 
 // Render renders a template with data and sends a text/html response with status
-// code. Renderer must be registered using `Echo.Renderer`.
+// code. Renderer must be registered using `Echo.Renderer` or `GroupConfig.Renderer`.
 func (c *Context) Render(code int, name string, data any) (err error) {
-	if c.echo.Renderer == nil {
+	renderer := c.renderer()
+	if renderer == nil {
 		return ErrRendererNotRegistered
 	}
 	// as Renderer.Render can fail, and in that case we need to delay sending status code to the client until
@@ -503,7 +519,7 @@ func (c *Context) Render(code int, name string, data any) (err error) {
 	// > the output writer.
 
 	buf := new(bytes.Buffer)
-	if err = c.echo.Renderer.Render(c, buf, name, data); err != nil {
+	if err = renderer.Render(c, buf, name, data); err != nil {
 		return
 	}
 	return c.HTMLBlob(code, buf.Bytes())
@@ -533,7 +549,7 @@ func (c *Context) jsonPBlob(code int, callback string, i any) (err error) {
 	if _, err = c.response.Write(jsonpOpen); err != nil {
 		return
 	}
-	if err = c.echo.JSONSerializer.Serialize(c, i, ""); err != nil {
+	if err = c.jsonSerializer().Serialize(c, i, ""); err != nil {
 		return
 	}
 	if _, err = c.response.Write(jsonpClose); err != nil {
@@ -561,7 +577,7 @@ func (c *Context) json(code int, i any, indent string) error {
 	}
 	defer c.SetResponse(resp)
 
-	return c.echo.JSONSerializer.Serialize(c, i, indent)
+	return c.jsonSerializer().Serialize(c, i, indent)
 }
 
 // JSON sends a JSON response with status code.
@@ -754,3 +770,41 @@ func (c *Context) SetLogger(logger *slog.Logger) {
 func (c *Context) Echo() *Echo {
 	return c.echo
 }
+
+// HTTPErrorHandler returns error handler for the current request. It is the error handler of the closest group (see
+// GroupConfig) of the matched route or `Echo.HTTPErrorHandler` when no group sets it. Middlewares handling errors
+// themselves should use it instead of `c.Echo().HTTPErrorHandler`.
+func (c *Context) HTTPErrorHandler() HTTPErrorHandler {
+	if c.group != nil && c.group.httpErrorHandler != nil {
+		return c.group.httpErrorHandler
+	}
+	return c.echo.HTTPErrorHandler
+}
+
+func (c *Context) binder() Binder {
+	if c.group != nil && c.group.binder != nil {
+		return c.group.binder
+	}
+	return c.echo.Binder
+}
+
+func (c *Context) validator() Validator {
+	if c.group != nil && c.group.validator != nil {
+		return c.group.validator
+	}
+	return c.echo.Validator
+}
+
+func (c *Context) renderer() Renderer {
+	if c.group != nil && c.group.renderer != nil {
+		return c.group.renderer
+	}
+	return c.echo.Renderer
+}
+
+func (c *Context) jsonSerializer() JSONSerializer {
+	if c.group != nil && c.group.jsonSerializer != nil {
+		return c.group.jsonSerializer
+	}
+	return c.echo.JSONSerializer
+}
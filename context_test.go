@@ -1553,3 +1553,27 @@ func TestRouteInfo(t *testing.T) {
 	orgRI.Name = "changed"
 	assert.NotEqual(t, expect, c.RouteInfo())
 }
+
+func TestRouteInfo_metadataFromGroupRoute(t *testing.T) {
+	e := New()
+	g := e.Group("/api")
+
+	var metadata map[string]any
+	_, err := g.AddRoute(Route{
+		Method: http.MethodGet,
+		Path:   "/users/:id",
+		Handler: func(c *Context) error {
+			metadata = c.RouteInfo().Metadata
+			return c.NoContent(http.StatusOK)
+		},
+		Metadata: map[string]any{"permission": "users:read"},
+	})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/1", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]any{"permission": "users:read"}, metadata)
+}
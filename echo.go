@@ -82,6 +82,8 @@ type Echo struct {
 	Renderer         Renderer
 	Validator        Validator
 	JSONSerializer   JSONSerializer
+	Encoders         map[string]ResponseEncoder
+	Decoders         map[string]BodyDecoder
 	IPExtractor      IPExtractor
 	OnAddRoute       func(route Route) error
 	HTTPErrorHandler HTTPErrorHandler
@@ -168,7 +170,15 @@ const (
 	MIMETextPlain              = "text/plain"
 	MIMETextPlainCharsetUTF8   = MIMETextPlain + "; " + charsetUTF8
 	MIMEMultipartForm          = "multipart/form-data"
+	MIMETextEventStream        = "text/event-stream"
 	MIMEOctetStream            = "application/octet-stream"
+
+	// MIMEApplicationMergePatchJSON is the content type for RFC 7396 JSON Merge Patch documents.
+	// https://www.rfc-editor.org/rfc/rfc7396
+	MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+	// MIMEApplicationJSONPatchJSON is the content type for RFC 6902 JSON Patch documents.
+	// https://www.rfc-editor.org/rfc/rfc6902
+	MIMEApplicationJSONPatchJSON = "application/json-patch+json"
 )
 
 const (
@@ -206,6 +216,7 @@ const (
 	HeaderSetCookie           = "Set-Cookie"
 	HeaderIfModifiedSince     = "If-Modified-Since"
 	HeaderLastModified        = "Last-Modified"
+	HeaderLastEventID         = "Last-Event-ID"
 	HeaderLocation            = "Location"
 	HeaderRetryAfter          = "Retry-After"
 	HeaderUpgrade             = "Upgrade"
@@ -287,7 +298,7 @@ type Config struct {
 	Binder Binder
 
 	// Validator provides optional struct validation after data binding.
-	// Commonly used with third-party validation libraries.
+	// Use StructValidator for validation by `validate` struct tags or third-party validation libraries.
 	// If not set, Context.Validate() returns ErrValidatorNotRegistered.
 	Validator Validator
 
@@ -301,6 +312,15 @@ type Config struct {
 	// If not set, DefaultJSONSerializer using encoding/json is used.
 	JSONSerializer JSONSerializer
 
+	// Encoders are response encoders by media type used by Context.Negotiate for media types that are not
+	// supported out of the box, like MIMEApplicationMsgpack or MIMEApplicationProtobuf.
+	Encoders map[string]ResponseEncoder
+
+	// Decoders are request body decoders by media type used by BindBody (and DefaultBinder) for media types that are
+	// not supported out of the box, like MIMEApplicationMsgpack or MIMEApplicationProtobuf. Decoders registered for
+	// MIMEApplicationJSON, MIMEApplicationXML etc. replace the built-in decoding.
+	Decoders map[string]BodyDecoder
+
 	// IPExtractor defines the strategy for extracting the real client IP address
 	// from requests, particularly important when behind proxies or load balancers.
 	// Used for rate limiting, access control, and logging.
@@ -373,6 +393,12 @@ func NewWithConfig(config Config) *Echo {
 	if config.JSONSerializer != nil {
 		e.JSONSerializer = config.JSONSerializer
 	}
+	if config.Encoders != nil {
+		e.Encoders = config.Encoders
+	}
+	if config.Decoders != nil {
+		e.Decoders = config.Decoders
+	}
 	if config.IPExtractor != nil {
 		e.IPExtractor = config.IPExtractor
 	}
@@ -755,13 +781,93 @@ func (e *Echo) Add(method, path string, handler HandlerFunc, middleware ...Middl
 
 // Group creates a new router group with prefix and optional group-level middleware.
 func (e *Echo) Group(prefix string, m ...MiddlewareFunc) (g *Group) {
-	g = &Group{
-		prefix:               prefix,
+	return e.group("", prefix, m)
+}
+
+// Host creates a new router group for routes that match only requests with given host and optional group-level
+// middleware. Host can contain placeholders (`{tenant}.example.com`) that match single part of the host and are
+// accessible as path parameters (`c.Param("tenant")`). Port is ignored when matching the host.
+//
+// When host matches but group has no route for request path, routes without host are tried before group 404/405
+// handling.
+//
+// Example: `e.Host("{tenant}.example.com").GET("/users", handler)`
+func (e *Echo) Host(host string, m ...MiddlewareFunc) (g *Group) {
+	return e.group(host, "", m)
+}
+
+// GroupWithConfig creates a new router group with configuration. Group can override Echo instance components
+// (HTTPErrorHandler, Binder, Validator, Renderer, JSONSerializer) and 404/405 handlers for its routes.
+//
+// Example:
+//
+//	api, err := e.GroupWithConfig(echo.GroupConfig{
+//		Prefix:           "/api",
+//		HTTPErrorHandler: echo.ProblemDetailsHTTPErrorHandler(false),
+//	})
+func (e *Echo) GroupWithConfig(config GroupConfig) (*Group, error) {
+	return e.groupWithConfig(nil, "", config)
+}
+
+func (e *Echo) group(host string, prefix string, m []MiddlewareFunc) *Group {
+	g, err := e.groupWithConfig(nil, host, GroupConfig{Prefix: prefix, Middlewares: m})
+	if err != nil {
+		panic(err) // this is how `v4` handles errors. `v5` has methods to have panic-free usage
+	}
+	return g
+}
+
+func (e *Echo) groupWithConfig(parent *Group, host string, config GroupConfig) (*Group, error) {
+	g := &Group{
+		host:                 host,
+		prefix:               config.Prefix,
 		echo:                 e,
 		noAutoRegisterRoutes: e.noGroupAutoRegisterRoutes,
 	}
-	g.Use(m...)
-	return
+	if parent != nil {
+		g.httpErrorHandler = parent.httpErrorHandler
+		g.binder = parent.binder
+		g.validator = parent.validator
+		g.renderer = parent.renderer
+		g.jsonSerializer = parent.jsonSerializer
+		g.notFoundHandler = parent.notFoundHandler
+		g.methodNotAllowedHandler = parent.methodNotAllowedHandler
+	}
+	if config.HTTPErrorHandler != nil {
+		g.httpErrorHandler = config.HTTPErrorHandler
+	}
+	if config.Binder != nil {
+		g.binder = config.Binder
+	}
+	if config.Validator != nil {
+		g.validator = config.Validator
+	}
+	if config.Renderer != nil {
+		g.renderer = config.Renderer
+	}
+	if config.JSONSerializer != nil {
+		g.jsonSerializer = config.JSONSerializer
+	}
+	if config.NotFoundHandler != nil {
+		g.notFoundHandler = config.NotFoundHandler
+	}
+	if config.MethodNotAllowedHandler != nil {
+		g.methodNotAllowedHandler = config.MethodNotAllowedHandler
+	}
+
+	g.middleware = config.Middlewares
+	if g.methodNotAllowedHandler != nil {
+		g.methodNotAllowedChain = applyMiddleware(g.methodNotAllowedHandler, g.middleware...)
+	}
+	// group with its own error or 404 handling needs 404 routes so requests under group prefix that do not match any
+	// group route are handled by the group. Sub-groups inheriting these handlers are covered by the parent 404 routes.
+	hasNotFoundHandling := config.HTTPErrorHandler != nil || config.NotFoundHandler != nil
+	if hasNotFoundHandling || (len(g.middleware) > 0 && !g.noAutoRegisterRoutes) {
+		if err := g.registerNotFoundRoutes(); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
 }
 
 // PreMiddlewares returns registered pre middlewares. These are middleware to the chain
@@ -800,10 +906,17 @@ func (e *Echo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // serveHTTP implements `http.Handler` interface, which serves HTTP requests.
 func (e *Echo) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	e.serve(w, r, "")
+}
+
+// serve serves HTTP request. mountPrefix is the path prefix Echo is mounted under (see Echo.Mount) and is already
+// stripped from the request path.
+func (e *Echo) serve(w http.ResponseWriter, r *http.Request, mountPrefix string) {
 	c := e.contextPool.Get().(*Context)
 	defer e.contextPool.Put(c)
 
 	c.Reset(r, w)
+	c.mountPrefix = mountPrefix
 
 	// The global (e.chain) and pre-middleware (e.preChain) chains are compiled once in buildRouterChains and
 	// reused here, so no middleware closures are allocated per request.
@@ -816,7 +929,7 @@ func (e *Echo) serveHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		e.HTTPErrorHandler(c, err)
+		c.HTTPErrorHandler()(c, err)
 	}
 }
 
@@ -13,9 +13,22 @@ import (
 // from the parent echo instance while still inheriting from it.
 type Group struct {
 	echo       *Echo
+	host       string
 	prefix     string
 	middleware []MiddlewareFunc
 
+	// components overriding Echo instance components for routes of this group and its sub-groups. Nil means that
+	// component of the parent group or Echo instance is used.
+	httpErrorHandler        HTTPErrorHandler
+	binder                  Binder
+	validator               Validator
+	renderer                Renderer
+	jsonSerializer          JSONSerializer
+	notFoundHandler         HandlerFunc
+	methodNotAllowedHandler HandlerFunc
+	// methodNotAllowedChain is methodNotAllowedHandler wrapped with group middlewares. Router executes it for 405 cases.
+	methodNotAllowedChain HandlerFunc
+
 	// noAutoRegisterRoutes is a flag that indicates whether Group should NOT register 404 routes automatically
 	// when there are middlewares registered with the group.
 	// Note: if you decide not to register 404 routes automatically, make sure to check if all your middlewares are executed
@@ -23,6 +36,42 @@ type Group struct {
 	noAutoRegisterRoutes bool
 }
 
+// GroupConfig is configuration for a router group created with Echo.GroupWithConfig or Group.GroupWithConfig.
+//
+// Components (HTTPErrorHandler, Binder, Validator, Renderer, JSONSerializer) override Echo instance components for
+// requests matching routes of the group and its sub-groups. Context resolves the component of the closest group of the
+// matched route and falls back to the Echo instance component when no group in the chain sets it.
+type GroupConfig struct {
+	// Prefix is the path prefix of the group routes.
+	Prefix string
+	// Middlewares are group level middlewares. See Group.Use.
+	Middlewares []MiddlewareFunc
+
+	// HTTPErrorHandler handles errors returned from handlers and middlewares of the group routes. It is also used
+	// for 404 and 405 errors for request paths under the group prefix.
+	// Optional. Default value Echo.HTTPErrorHandler
+	HTTPErrorHandler HTTPErrorHandler
+	// Binder is used by Context.Bind for group routes.
+	// Optional. Default value Echo.Binder
+	Binder Binder
+	// Validator is used by Context.Validate for group routes.
+	// Optional. Default value Echo.Validator
+	Validator Validator
+	// Renderer is used by Context.Render for group routes.
+	// Optional. Default value Echo.Renderer
+	Renderer Renderer
+	// JSONSerializer is used to serialize and deserialize JSON for group routes.
+	// Optional. Default value Echo.JSONSerializer
+	JSONSerializer JSONSerializer
+
+	// NotFoundHandler is executed when request path is under the group prefix but no group route matches it (404).
+	// Optional. Default value is Router NotFoundHandler
+	NotFoundHandler HandlerFunc
+	// MethodNotAllowedHandler is executed when a group route matches request path but not request method (405).
+	// Optional. Default value is Router MethodNotAllowedHandler
+	MethodNotAllowedHandler HandlerFunc
+}
+
 // Use implements `Echo#Use()` for sub-routes within the Group.
 //
 // Important! Group middlewares are executed in case there was no exact route match as by default Group registers
@@ -30,6 +79,9 @@ type Group struct {
 // flag set to true. Example `echo.NewWithConfig(echo.Config{NoGroupAutoRegister404Routes: true})`.
 func (g *Group) Use(middleware ...MiddlewareFunc) {
 	g.middleware = append(g.middleware, middleware...)
+	if g.methodNotAllowedHandler != nil {
+		g.methodNotAllowedChain = applyMiddleware(g.methodNotAllowedHandler, g.middleware...)
+	}
 	if len(g.middleware) == 0 {
 		return
 	}
@@ -40,13 +92,21 @@ func (g *Group) Use(middleware ...MiddlewareFunc) {
 	// are only executed if they are added to the Router with route.
 	// So we register catch all route (404 is a safe way to emulate route match) for this group and now during routing the
 	// Router would find route to match our request path and therefore guarantee the middleware(s) will get executed.
-	// Note: we use nil handler so Router would choose the default 404 handler. This may not work with custom routers.
-	if _, err := g.AddRoute(Route{Method: RouteNotFound, Path: "", allowOverwrite: true}); err != nil {
+	if err := g.registerNotFoundRoutes(); err != nil {
 		panic(err) // this is how `v4` handles errors. `v5` has methods to have panic-free usage
 	}
-	if _, err := g.AddRoute(Route{Method: RouteNotFound, Path: "/*", allowOverwrite: true}); err != nil {
-		panic(err) // this is how `v4` handles errors. `v5` has methods to have panic-free usage
+}
+
+func (g *Group) registerNotFoundRoutes() error {
+	// Note: we use nil handler (when group has no NotFoundHandler) so Router would choose the default 404 handler.
+	// This may not work with custom routers.
+	if _, err := g.AddRoute(Route{Method: RouteNotFound, Path: "", Handler: g.notFoundHandler, allowOverwrite: true}); err != nil {
+		return err
+	}
+	if _, err := g.AddRoute(Route{Method: RouteNotFound, Path: "/*", Handler: g.notFoundHandler, allowOverwrite: true}); err != nil {
+		return err
 	}
+	return nil
 }
 
 // CONNECT implements `Echo#CONNECT()` for sub-routes within the Group. Panics on error.
@@ -133,11 +193,22 @@ func (g *Group) Match(methods []string, path string, handler HandlerFunc, middle
 // `/*` NotFound routes for itself. If this kind of behavior is not needed, then create an Echo instance with the ` noAutoRegisterRoutes `
 // flag set to true. Example `echo.NewWithConfig(echo.Config{NoGroupAutoRegister404Routes: true})`.
 func (g *Group) Group(prefix string, middleware ...MiddlewareFunc) (sg *Group) {
-	m := make([]MiddlewareFunc, 0, len(g.middleware)+len(middleware))
+	sg, err := g.GroupWithConfig(GroupConfig{Prefix: prefix, Middlewares: middleware})
+	if err != nil {
+		panic(err) // this is how `v4` handles errors. `v5` has methods to have panic-free usage
+	}
+	return sg
+}
+
+// GroupWithConfig creates a new sub-group with configuration. Sub-group inherits middlewares and components of
+// this group. Components set in the config override inherited ones.
+func (g *Group) GroupWithConfig(config GroupConfig) (*Group, error) {
+	m := make([]MiddlewareFunc, 0, len(g.middleware)+len(config.Middlewares))
 	m = append(m, g.middleware...)
-	m = append(m, middleware...)
-	sg = g.echo.Group(g.prefix+prefix, m...)
-	return
+	m = append(m, config.Middlewares...)
+	config.Prefix = g.prefix + config.Prefix
+	config.Middlewares = m
+	return g.echo.groupWithConfig(g, g.host, config)
 }
 
 // Static implements `Echo#Static()` for sub-routes within the Group.
@@ -206,5 +277,11 @@ func (g *Group) AddRoute(route Route) (RouteInfo, error) {
 	// multiple routes, which would lead to later add() calls overwriting the
 	// middleware from earlier calls.
 	groupRoute := route.WithPrefix(g.prefix, append([]MiddlewareFunc{}, g.middleware...))
+	if groupRoute.Host == "" {
+		groupRoute.Host = g.host
+	}
+	if groupRoute.group == nil {
+		groupRoute.group = g
+	}
 	return g.echo.add(groupRoute)
 }
@@ -4,6 +4,8 @@
 package echo
 
 import (
+	"errors"
+	"io"
 	"io/fs"
 	"net/http"
 	"net/http/httptest"
@@ -982,3 +984,262 @@ func TestGroup_RouteNotFoundUsesRouterConfig(t *testing.T) {
 	assert.Equal(t, "custom-404 POST /v0/*", rec.Body.String())
 	assert.True(t, middlewareCalled, "group middleware must wrap the auto 404 route")
 }
+
+// groupComponent implements Binder, Validator, Renderer and JSONSerializer and reports its name in results.
+type groupComponent struct {
+	name string
+}
+
+func (gc groupComponent) Bind(c *Context, target any) error {
+	if u, ok := target.(*user); ok {
+		u.Name = gc.name
+	}
+	return nil
+}
+
+func (gc groupComponent) Validate(i any) error {
+	return errors.New(gc.name + " validator")
+}
+
+func (gc groupComponent) Render(c *Context, w io.Writer, name string, data any) error {
+	_, err := io.WriteString(w, gc.name+" "+name)
+	return err
+}
+
+func (gc groupComponent) Serialize(c *Context, i any, indent string) error {
+	_, err := io.WriteString(c.Response(), gc.name+" json")
+	return err
+}
+
+func (gc groupComponent) Deserialize(c *Context, i any) error {
+	return nil
+}
+
+func TestGroupWithConfig_components(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		whenURL      string
+		expectHeader string
+		expectBody   string
+	}{
+		{
+			name:         "ok, api group components",
+			whenURL:      "/api/components",
+			expectHeader: "api|api validator",
+			expectBody:   "api index",
+		},
+		{
+			name:         "ok, api group json serializer",
+			whenURL:      "/api/components?json=true",
+			expectHeader: "api|api validator",
+			expectBody:   "api json",
+		},
+		{
+			name:         "ok, sub-group overrides only renderer",
+			whenURL:      "/api/v1/components",
+			expectHeader: "api|api validator",
+			expectBody:   "v1 index",
+		},
+		{
+			name:         "ok, routes without group use Echo components",
+			whenURL:      "/components",
+			expectHeader: "echo|echo validator",
+			expectBody:   "echo index",
+		},
+		{
+			name:         "ok, routes without group use Echo json serializer",
+			whenURL:      "/components?json=true",
+			expectHeader: "echo|echo validator",
+			expectBody:   "echo json",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := func(c *Context) error {
+				u := user{}
+				if err := c.Bind(&u); err != nil {
+					return err
+				}
+				c.Response().Header().Set("X-Result", u.Name+"|"+c.Validate(u).Error())
+				if c.QueryParam("json") != "" {
+					return c.JSON(http.StatusOK, u)
+				}
+				return c.Render(http.StatusOK, "index", u)
+			}
+
+			e := New()
+			echoComponent := groupComponent{name: "echo"}
+			e.Binder = echoComponent
+			e.Validator = echoComponent
+			e.Renderer = echoComponent
+			e.JSONSerializer = echoComponent
+			e.GET("/components", handler)
+
+			apiComponent := groupComponent{name: "api"}
+			api, err := e.GroupWithConfig(GroupConfig{
+				Prefix:         "/api",
+				Binder:         apiComponent,
+				Validator:      apiComponent,
+				Renderer:       apiComponent,
+				JSONSerializer: apiComponent,
+			})
+			assert.NoError(t, err)
+			api.GET("/components", handler)
+
+			v1, err := api.GroupWithConfig(GroupConfig{Prefix: "/v1", Renderer: groupComponent{name: "v1"}})
+			assert.NoError(t, err)
+			v1.GET("/components", handler)
+
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectHeader, rec.Header().Get("X-Result"))
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGroupWithConfig_errorHandling(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		whenMethod  string
+		whenURL     string
+		expectCode  int
+		expectBody  string
+		expectAllow string
+	}{
+		{
+			name:       "nok, api handler error is handled by api error handler",
+			whenMethod: http.MethodGet,
+			whenURL:    "/api/error",
+			expectCode: http.StatusBadRequest,
+			expectBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid"}` + "\n",
+		},
+		{
+			name:       "nok, api not found is handled by api error handler",
+			whenMethod: http.MethodGet,
+			whenURL:    "/api/unknown",
+			expectCode: http.StatusNotFound,
+			expectBody: `{"type":"about:blank","title":"Not Found","status":404}` + "\n",
+		},
+		{
+			name:        "nok, api method not allowed is handled by api error handler",
+			whenMethod:  http.MethodPost,
+			whenURL:     "/api/error",
+			expectCode:  http.StatusMethodNotAllowed,
+			expectBody:  `{"type":"about:blank","title":"Method Not Allowed","status":405}` + "\n",
+			expectAllow: "OPTIONS, GET",
+		},
+		{
+			name:       "nok, sub-group inherits api error handler",
+			whenMethod: http.MethodGet,
+			whenURL:    "/api/v1/error",
+			expectCode: http.StatusBadRequest,
+			expectBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"invalid"}` + "\n",
+		},
+		{
+			name:       "nok, web not found handler",
+			whenMethod: http.MethodGet,
+			whenURL:    "/web/unknown",
+			expectCode: http.StatusNotFound,
+			expectBody: "<h1>web 404 /web/*</h1>",
+		},
+		{
+			name:        "nok, web method not allowed handler",
+			whenMethod:  http.MethodPost,
+			whenURL:     "/web/error",
+			expectCode:  http.StatusMethodNotAllowed,
+			expectBody:  "<h1>web 405</h1>",
+			expectAllow: "",
+		},
+		{
+			name:       "nok, web handler error is handled by web error handler",
+			whenMethod: http.MethodGet,
+			whenURL:    "/web/error",
+			expectCode: http.StatusBadRequest,
+			expectBody: "<h1>web error: code=400, message=invalid</h1>",
+		},
+		{
+			name:       "nok, routes without group use Echo error handler",
+			whenMethod: http.MethodGet,
+			whenURL:    "/unknown",
+			expectCode: http.StatusNotFound,
+			expectBody: `{"message":"Not Found"}` + "\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errHandler := func(c *Context) error {
+				return NewHTTPError(http.StatusBadRequest, "invalid")
+			}
+
+			e := New()
+			api, err := e.GroupWithConfig(GroupConfig{
+				Prefix:           "/api",
+				HTTPErrorHandler: ProblemDetailsHTTPErrorHandler(false),
+			})
+			assert.NoError(t, err)
+			api.GET("/error", errHandler)
+			api.Group("/v1").GET("/error", errHandler)
+
+			web, err := e.GroupWithConfig(GroupConfig{
+				Prefix: "/web",
+				HTTPErrorHandler: func(c *Context, err error) {
+					_ = c.HTML(StatusCode(err), "<h1>web error: "+err.Error()+"</h1>")
+				},
+				NotFoundHandler: func(c *Context) error {
+					return c.HTML(http.StatusNotFound, "<h1>web 404 "+c.Path()+"</h1>")
+				},
+				MethodNotAllowedHandler: func(c *Context) error {
+					return c.HTML(http.StatusMethodNotAllowed, "<h1>web 405</h1>")
+				},
+			})
+			assert.NoError(t, err)
+			web.GET("/error", errHandler)
+
+			req := httptest.NewRequest(tc.whenMethod, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+			assert.Equal(t, tc.expectAllow, rec.Header().Get(HeaderAllow))
+		})
+	}
+}
+
+func TestGroupWithConfig_methodNotAllowedHandlerWithMiddleware(t *testing.T) {
+	e := New()
+	g, err := e.GroupWithConfig(GroupConfig{
+		Prefix: "/group",
+		Middlewares: []MiddlewareFunc{func(next HandlerFunc) HandlerFunc {
+			return func(c *Context) error {
+				c.Response().Header().Set("X-Group", "true")
+				return next(c)
+			}
+		}},
+		MethodNotAllowedHandler: func(c *Context) error {
+			return c.String(http.StatusMethodNotAllowed, "group 405 "+c.RouteInfo().Name)
+		},
+	})
+	assert.NoError(t, err)
+	g.GET("/resource", handlerFunc)
+
+	req := httptest.NewRequest(http.MethodPost, "/group/resource", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "group 405 "+MethodNotAllowedRouteName, rec.Body.String())
+	assert.Equal(t, "true", rec.Header().Get("X-Group"))
+
+	// preflight requests are routed to group 404 route so group middlewares (CORS) can handle them
+	req = httptest.NewRequest(http.MethodOptions, "/group/resource", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("X-Group"))
+}
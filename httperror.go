@@ -16,6 +16,7 @@ var (
 	ErrForbidden                   = &httpError{http.StatusForbidden}             // 403
 	ErrNotFound                    = &httpError{http.StatusNotFound}              // 404
 	ErrMethodNotAllowed            = &httpError{http.StatusMethodNotAllowed}      // 405
+	ErrNotAcceptable               = &httpError{http.StatusNotAcceptable}         // 406
 	ErrRequestTimeout              = &httpError{http.StatusRequestTimeout}        // 408
 	ErrStatusRequestEntityTooLarge = &httpError{http.StatusRequestEntityTooLarge} // 413
 	ErrUnsupportedMediaType        = &httpError{http.StatusUnsupportedMediaType}  // 415
@@ -29,10 +30,13 @@ var (
 var (
 	ErrValidatorNotRegistered = errors.New("validator not registered")
 	ErrRendererNotRegistered  = errors.New("renderer not registered")
+	ErrEncoderNotRegistered   = errors.New("encoder not registered")
 	ErrInvalidRedirectCode    = errors.New("invalid redirect status code")
 	ErrCookieNotFound         = errors.New("cookie not found")
 	ErrInvalidCertOrKeyType   = errors.New("invalid cert or key type, must be string or []byte")
 	ErrInvalidListenerNetwork = errors.New("invalid listener network")
+	ErrInvalidDefaultTag      = errors.New("invalid default struct tag value")
+	ErrInvalidValidationTag   = errors.New("invalid validate struct tag value")
 )
 
 // HTTPStatusCoder is an interface that errors can implement to produce status code for HTTP response
@@ -5,12 +5,31 @@ package echo
 
 import (
 	"bytes"
+	"cmp"
+	"encoding"
 	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 )
 
+// DefaultJSONMaxDepth is the default maximum nesting depth of JSON objects and arrays in strict decoding mode.
+const DefaultJSONMaxDepth = 32
+
 // DefaultJSONSerializer implements JSON encoding using encoding/json.
-type DefaultJSONSerializer struct{}
+type DefaultJSONSerializer struct {
+	// Strict enables strict decoding of request bodies. In strict mode unknown object fields, duplicate object keys,
+	// data after the top-level value and nesting deeper than MaxDepth are rejected with BindingError that has JSON
+	// pointer (RFC 6901) to the offending value as its Field. For strict decoding of a single request use
+	// BindJSONStrict.
+	Strict bool
+	// MaxDepth is maximum nesting depth of objects and arrays in strict mode.
+	// Optional. Default value DefaultJSONMaxDepth.
+	MaxDepth int
+}
 
 // jsonBufPool reuses buffers for reading request bodies during JSON
 // deserialization, avoiding the per-request decoder and its internal read
@@ -56,8 +75,461 @@ func (d DefaultJSONSerializer) Deserialize(c *Context, target any) error {
 	if _, err := buf.ReadFrom(c.Request().Body); err != nil {
 		return ErrBadRequest.Wrap(err)
 	}
+	if d.Strict {
+		return decodeJSONStrict(buf.Bytes(), target, cmp.Or(d.MaxDepth, DefaultJSONMaxDepth))
+	}
 	if err := json.Unmarshal(buf.Bytes(), target); err != nil {
 		return ErrBadRequest.Wrap(err)
 	}
 	return nil
 }
+
+// BindJSONStrict binds JSON request body to target with strict decoding (see DefaultJSONSerializer.Strict)
+// regardless of the JSONSerializer registered with Echo. Use it for endpoints that need strict decoding when it is
+// not enabled globally.
+func BindJSONStrict(c *Context, target any) error {
+	return DefaultJSONSerializer{Strict: true}.Deserialize(c, target)
+}
+
+func decodeJSONStrict(data []byte, target any, maxDepth int) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return ErrBadRequest.Wrap(&json.InvalidUnmarshalError{Type: reflect.TypeOf(target)})
+	}
+	d := strictJSONDecoder{
+		dec:      json.NewDecoder(bytes.NewReader(data)),
+		maxDepth: maxDepth,
+	}
+	// decode into copy so target is not partially filled when input is rejected
+	result := reflect.New(rv.Elem().Type()).Elem()
+	result.Set(rv.Elem())
+	if err := d.value(result, 0); err != nil {
+		return err
+	}
+	if _, err := d.dec.Token(); err != io.EOF {
+		return NewBindingError("", nil, "unexpected data after top-level value", err)
+	}
+	rv.Elem().Set(result)
+	return nil
+}
+
+// strictJSONDecoder decodes JSON value into target while checking the rules of strict decoding. Objects and arrays
+// are walked token by token with the same decoder that decodes other values into their targets, so input is read
+// only once. Values of types that unmarshal themselves are decoded as a whole and are not checked.
+type strictJSONDecoder struct {
+	dec      *json.Decoder
+	maxDepth int
+	// path contains reference tokens of the current value
+	path []string
+}
+
+func (d *strictJSONDecoder) error(message string, err error) error {
+	return NewBindingError(jsonPointer(d.path), nil, message, err)
+}
+
+func (d *strictJSONDecoder) typeError(value string, typ reflect.Type) error {
+	return d.error("invalid value type", &json.UnmarshalTypeError{Value: value, Type: typ, Offset: d.dec.InputOffset()})
+}
+
+// decode decodes next JSON value into v with the standard library decoder.
+func (d *strictJSONDecoder) decode(v reflect.Value) error {
+	if err := d.dec.Decode(v.Addr().Interface()); err != nil {
+		var tErr *json.UnmarshalTypeError
+		var sErr *json.SyntaxError
+		switch {
+		case errors.As(err, &tErr):
+			return d.error("invalid value type", err)
+		case errors.As(err, &sErr), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+			return d.error("invalid JSON", err)
+		}
+		return ErrBadRequest.Wrap(err)
+	}
+	return nil
+}
+
+// value decodes next JSON value into v.
+func (d *strictJSONDecoder) value(v reflect.Value, depth int) error {
+	if !isStrictJSONWalkable(v.Type()) {
+		return d.decode(v)
+	}
+	tok, err := d.dec.Token()
+	if err != nil {
+		return d.error("invalid JSON", err)
+	}
+	if tok == nil { // null
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+			v.SetZero()
+		}
+		return nil
+	}
+	delim, isDelim := tok.(json.Delim)
+	if isDelim && depth >= d.maxDepth {
+		return d.error("maximum nesting depth exceeded", nil)
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Interface {
+		value, err := d.anyValue(tok, depth)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	switch {
+	case delim == '[' && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array):
+		return d.array(v, depth)
+	case delim == '{' && v.Kind() == reflect.Struct:
+		return d.object(jsonStructFields(v.Type()), v, depth)
+	case delim == '{' && v.Kind() == reflect.Map:
+		return d.object(nil, v, depth)
+	}
+	var value string
+	switch tok.(type) {
+	case json.Delim:
+		value = "object"
+		if delim == '[' {
+			value = "array"
+		}
+	case string:
+		value = "string"
+	case bool:
+		value = "bool"
+	default:
+		value = "number"
+	}
+	return d.typeError(value, v.Type())
+}
+
+// array decodes elements of JSON array into slice or array v.
+func (d *strictJSONDecoder) array(v reflect.Value, depth int) error {
+	i := 0
+	if v.Kind() == reflect.Slice {
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	}
+	for ; d.dec.More(); i++ {
+		d.path = append(d.path, strconv.Itoa(i))
+		var elem reflect.Value
+		switch {
+		case v.Kind() == reflect.Slice:
+			v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+			elem = v.Index(i)
+		case i < v.Len():
+			elem = v.Index(i)
+		default:
+			elem = reflect.New(v.Type().Elem()).Elem() // extra elements are discarded
+		}
+		if err := d.value(elem, depth+1); err != nil {
+			return err
+		}
+		d.path = d.path[:len(d.path)-1]
+	}
+	for ; i < v.Len(); i++ {
+		v.Index(i).SetZero()
+	}
+	if _, err := d.dec.Token(); err != nil {
+		return d.error("invalid JSON", err)
+	}
+	return nil
+}
+
+// object decodes members of JSON object into struct (fields is not nil) or map v.
+func (d *strictJSONDecoder) object(fields *jsonStructInfo, v reflect.Value, depth int) error {
+	if fields == nil && v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	seen := make(map[string]struct{})
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return d.error("invalid JSON", err)
+		}
+		key, _ := tok.(string)
+		d.path = append(d.path, key)
+
+		if fields == nil {
+			if _, ok := seen[key]; ok {
+				return d.error("duplicate object key", nil)
+			}
+			seen[key] = struct{}{}
+			if err := d.mapValue(v, key, depth); err != nil {
+				return err
+			}
+		} else {
+			f := fields.field(key)
+			if f == nil {
+				return d.error("unknown field", nil)
+			}
+			// keys are matched to fields case-insensitively, so duplicates are checked by the field
+			if _, ok := seen[f.name]; ok {
+				return d.error("duplicate object key", nil)
+			}
+			seen[f.name] = struct{}{}
+			if err := d.field(v, f, depth); err != nil {
+				return err
+			}
+		}
+		d.path = d.path[:len(d.path)-1]
+	}
+	if _, err := d.dec.Token(); err != nil {
+		return d.error("invalid JSON", err)
+	}
+	return nil
+}
+
+func (d *strictJSONDecoder) mapValue(m reflect.Value, key string, depth int) error {
+	kt := m.Type().Key()
+	kv := reflect.New(kt).Elem()
+	switch {
+	case reflect.PointerTo(kt).Implements(textUnmarshalerType):
+		if err := kv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(key)); err != nil {
+			return d.error("invalid object key", err)
+		}
+	case kt.Kind() == reflect.String:
+		kv.SetString(key)
+	case kv.CanInt():
+		n, err := strconv.ParseInt(key, 10, kt.Bits())
+		if err != nil {
+			return d.typeError("number "+key, kt)
+		}
+		kv.SetInt(n)
+	case kv.CanUint():
+		n, err := strconv.ParseUint(key, 10, kt.Bits())
+		if err != nil {
+			return d.typeError("number "+key, kt)
+		}
+		kv.SetUint(n)
+	default:
+		return d.typeError("string", kt)
+	}
+	elem := reflect.New(m.Type().Elem()).Elem()
+	if err := d.value(elem, depth+1); err != nil {
+		return err
+	}
+	m.SetMapIndex(kv, elem)
+	return nil
+}
+
+func (d *strictJSONDecoder) field(v reflect.Value, f *jsonField, depth int) error {
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	if !f.quoted {
+		return d.value(v, depth+1)
+	}
+	// `json:",string"` option, value is encoded inside JSON string
+	var quoted string
+	if err := d.decode(reflect.ValueOf(&quoted).Elem()); err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(quoted), v.Addr().Interface()); err != nil {
+		return d.typeError("string", v.Type())
+	}
+	return nil
+}
+
+// anyValue decodes JSON value starting with token tok into value of empty interface.
+func (d *strictJSONDecoder) anyValue(tok json.Token, depth int) (any, error) {
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+	if depth >= d.maxDepth {
+		return nil, d.error("maximum nesting depth exceeded", nil)
+	}
+	if delim == '[' {
+		result := make([]any, 0)
+		for i := 0; d.dec.More(); i++ {
+			d.path = append(d.path, strconv.Itoa(i))
+			value, err := d.nextAnyValue(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+			d.path = d.path[:len(d.path)-1]
+		}
+		if _, err := d.dec.Token(); err != nil {
+			return nil, d.error("invalid JSON", err)
+		}
+		return result, nil
+	}
+
+	result := make(map[string]any)
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return nil, d.error("invalid JSON", err)
+		}
+		key, _ := tok.(string)
+		d.path = append(d.path, key)
+		if _, ok := result[key]; ok {
+			return nil, d.error("duplicate object key", nil)
+		}
+		value, err := d.nextAnyValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+		d.path = d.path[:len(d.path)-1]
+	}
+	if _, err := d.dec.Token(); err != nil {
+		return nil, d.error("invalid JSON", err)
+	}
+	return result, nil
+}
+
+func (d *strictJSONDecoder) nextAnyValue(depth int) (any, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return nil, d.error("invalid JSON", err)
+	}
+	return d.anyValue(tok, depth)
+}
+
+var (
+	jsonUnmarshalerType  = reflect.TypeFor[json.Unmarshaler]()
+	jsonStructFieldCache sync.Map // map[reflect.Type]*jsonStructInfo
+)
+
+// isStrictJSONWalkable checks if values of the type are walked by strictJSONDecoder. Other values (scalars and types
+// that unmarshal themselves) are decoded with the standard library decoder.
+func isStrictJSONWalkable(typ reflect.Type) bool {
+	for {
+		ptrTyp := reflect.PointerTo(typ)
+		if ptrTyp.Implements(jsonUnmarshalerType) || ptrTyp.Implements(textUnmarshalerType) {
+			return false
+		}
+		if typ.Kind() != reflect.Pointer {
+			break
+		}
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Array:
+		return true
+	case reflect.Slice:
+		return typ.Elem().Kind() != reflect.Uint8 // []byte is base64 encoded string
+	case reflect.Interface:
+		return typ.NumMethod() == 0
+	}
+	return false
+}
+
+// jsonStructInfo contains fields of the struct by JSON object key.
+type jsonStructInfo struct {
+	fields map[string]*jsonField
+	// folded contains fields by lower case key as encoding/json matches keys case-insensitively
+	folded map[string]*jsonField
+}
+
+type jsonField struct {
+	name   string
+	index  []int
+	quoted bool
+}
+
+func (s *jsonStructInfo) field(key string) *jsonField {
+	if f, ok := s.fields[key]; ok {
+		return f
+	}
+	return s.folded[strings.ToLower(key)]
+}
+
+// jsonStructFields returns fields of the struct by JSON object key, including fields promoted from embedded structs.
+func jsonStructFields(typ reflect.Type) *jsonStructInfo {
+	if cached, ok := jsonStructFieldCache.Load(typ); ok {
+		return cached.(*jsonStructInfo)
+	}
+	info := &jsonStructInfo{
+		fields: make(map[string]*jsonField),
+		folded: make(map[string]*jsonField),
+	}
+	var embedded []reflect.StructField
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			t := f.Type
+			if t.Kind() == reflect.Pointer {
+				if !f.IsExported() {
+					continue // pointer to unexported struct can not be allocated
+				}
+				t = t.Elem()
+			}
+			if t.Kind() == reflect.Struct {
+				embedded = append(embedded, f)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		jf := &jsonField{name: name, index: f.Index, quoted: opts == "string" && isJSONQuotable(f.Type)}
+		info.fields[name] = jf
+		info.folded[strings.ToLower(name)] = jf
+	}
+	for _, ef := range embedded {
+		t := ef.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		for name, f := range jsonStructFields(t).fields {
+			if _, ok := info.fields[name]; ok { // fields of outer struct have priority
+				continue
+			}
+			jf := &jsonField{name: name, index: append(ef.Index[:len(ef.Index):len(ef.Index)], f.index...), quoted: f.quoted}
+			info.fields[name] = jf
+			if _, ok := info.folded[strings.ToLower(name)]; !ok {
+				info.folded[strings.ToLower(name)] = jf
+			}
+		}
+	}
+	jsonStructFieldCache.Store(typ, info)
+	return info
+}
+
+// isJSONQuotable checks if `json:",string"` option applies to the type.
+func isJSONQuotable(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// jsonPointer creates JSON pointer (RFC 6901) from reference tokens.
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(jsonPointerEscaper.Replace(t))
+	}
+	return sb.String()
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
@@ -4,6 +4,7 @@
 package echo
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -214,3 +215,198 @@ func TestDefaultJSONCodec_Decode_BodyReadError(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, err.(*HTTPError).Code)
 	}
 }
+
+func TestDefaultJSONCodec_Decode_Strict(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type embedded struct {
+		Tags []string `json:"tags"`
+	}
+	type target struct {
+		embedded
+		ID        int               `json:"id"`
+		Name      string            `json:"name"`
+		Addresses []address         `json:"addresses"`
+		Extra     map[string]any    `json:"extra"`
+		Labels    map[string]string `json:"labels"`
+		Raw       json.RawMessage   `json:"raw"`
+		Ignored   string            `json:"-"`
+		Untagged  bool
+	}
+
+	var testCases = []struct {
+		name          string
+		whenBody      string
+		whenMaxDepth  int
+		expect        target
+		expectError   string
+		expectPointer string
+	}{
+		{
+			name:     "ok",
+			whenBody: `{"id":1,"NAME":"Jon","addresses":[{"city":"Tallinn"}],"tags":["a"],"extra":{"any":{"thing":[1]}},"raw":{"x":1},"untagged":true}`,
+			expect: target{
+				embedded:  embedded{Tags: []string{"a"}},
+				ID:        1,
+				Name:      "Jon",
+				Addresses: []address{{City: "Tallinn"}},
+				Extra:     map[string]any{"any": map[string]any{"thing": []any{float64(1)}}},
+				Raw:       json.RawMessage(`{"x":1}`),
+				Untagged:  true,
+			},
+		},
+		{
+			name:          "nok, unknown field",
+			whenBody:      `{"id":1,"nmae":"Jon"}`,
+			expectError:   "code=400, message=unknown field, field=/nmae",
+			expectPointer: "/nmae",
+		},
+		{
+			name:          "nok, unknown field in slice element",
+			whenBody:      `{"addresses":[{"city":"Tallinn"},{"town":"Tartu"}]}`,
+			expectError:   "code=400, message=unknown field, field=/addresses/1/town",
+			expectPointer: "/addresses/1/town",
+		},
+		{
+			name:          "nok, ignored field is unknown",
+			whenBody:      `{"Ignored":"x"}`,
+			expectError:   "code=400, message=unknown field, field=/Ignored",
+			expectPointer: "/Ignored",
+		},
+		{
+			name:          "nok, duplicate key",
+			whenBody:      `{"labels":{"a/b":"1","a/b":"2"}}`,
+			expectError:   "code=400, message=duplicate object key, field=/labels/a~1b",
+			expectPointer: "/labels/a~1b",
+		},
+		{
+			name:          "nok, duplicate key with different case",
+			whenBody:      `{"name":"a","Name":"b"}`,
+			expectError:   "code=400, message=duplicate object key, field=/Name",
+			expectPointer: "/Name",
+		},
+		{
+			name:          "nok, duplicate key in interface value",
+			whenBody:      `{"extra":{"a":[{"b":1,"b":2}]}}`,
+			expectError:   "code=400, message=duplicate object key, field=/extra/a/0/b",
+			expectPointer: "/extra/a/0/b",
+		},
+		{
+			name:          "nok, invalid value type for object",
+			whenBody:      `{"addresses":{"city":"Tallinn"}}`,
+			expectError:   "code=400, message=invalid value type, err=json: cannot unmarshal object into Go value of type []echo.address, field=/addresses",
+			expectPointer: "/addresses",
+		},
+		{
+			name:          "nok, trailing data",
+			whenBody:      `{"id":1} {"id":2}`,
+			expectError:   "code=400, message=unexpected data after top-level value, field=",
+			expectPointer: "",
+		},
+		{
+			name:          "nok, max depth",
+			whenBody:      `{"extra":{"a":{"b":{}}}}`,
+			whenMaxDepth:  3,
+			expectError:   "code=400, message=maximum nesting depth exceeded, field=/extra/a/b",
+			expectPointer: "/extra/a/b",
+		},
+		{
+			name:          "nok, invalid value type",
+			whenBody:      `{"addresses":[{"city":1}]}`,
+			expectError:   "code=400, message=invalid value type, err=json: cannot unmarshal number into Go value of type string, field=/addresses/0/city",
+			expectPointer: "/addresses/0/city",
+		},
+		{
+			name:          "nok, invalid JSON",
+			whenBody:      `{"id":1,"name":}`,
+			expectError:   "code=400, message=invalid JSON, err=invalid character '}' looking for beginning of value, field=/name",
+			expectPointer: "/name",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.whenBody))
+			req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+			e := New()
+			e.JSONSerializer = DefaultJSONSerializer{Strict: true, MaxDepth: tc.whenMaxDepth}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			var result target
+			err := c.Bind(&result)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				var bErr *BindingError
+				if assert.True(t, errors.As(err, &bErr)) {
+					assert.Equal(t, tc.expectPointer, bErr.Field)
+					assert.Equal(t, http.StatusBadRequest, bErr.Code)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, result)
+		})
+	}
+}
+
+func TestDefaultJSONCodec_Decode_StrictTypes(t *testing.T) {
+	type inner struct {
+		Value int `json:"value"`
+	}
+	type Embedded struct {
+		Note string `json:"note"`
+	}
+	type target struct {
+		*Embedded
+		Count    int64          `json:"count,string"`
+		Ptr      *inner         `json:"ptr"`
+		Nil      *inner         `json:"nil"`
+		Array    [2]int         `json:"array"`
+		ByID     map[int]string `json:"by_id"`
+		Bytes    []byte         `json:"bytes"`
+		Empty    []string       `json:"empty"`
+		Anything any            `json:"anything"`
+	}
+
+	result := target{Nil: &inner{Value: 1}, Array: [2]int{9, 9}}
+	err := decodeJSONStrict([]byte(`{
+		"note":"x",
+		"count":"42",
+		"ptr":{"value":1},
+		"nil":null,
+		"array":[1],
+		"by_id":{"1":"a"},
+		"bytes":"AQI=",
+		"empty":[],
+		"anything":"str"
+	}`), &result, DefaultJSONMaxDepth)
+
+	assert.NoError(t, err)
+	assert.Equal(t, target{
+		Embedded: &Embedded{Note: "x"},
+		Count:    42,
+		Ptr:      &inner{Value: 1},
+		Array:    [2]int{1, 0},
+		ByID:     map[int]string{1: "a"},
+		Bytes:    []byte{1, 2},
+		Empty:    []string{},
+		Anything: "str",
+	}, result)
+}
+
+func TestBindJSONStrict(t *testing.T) {
+	e := New()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":1,"name":"Jon","admin":true}`))
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	var u user
+	err := BindJSONStrict(c, &u)
+	assert.EqualError(t, err, "code=400, message=unknown field, field=/admin")
+
+	// global serializer is not strict
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":1,"name":"Jon","admin":true}`))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	c = e.NewContext(req, httptest.NewRecorder())
+	assert.NoError(t, c.Bind(&u))
+	assert.Equal(t, user{ID: 1, Name: "Jon"}, u)
+}
@@ -0,0 +1,427 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package middleware
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v5"
+)
+
+// ClientCertConfig defines the config for ClientCert middleware.
+type ClientCertConfig struct {
+	// Skipper defines a function to skip middleware.
+	Skipper Skipper
+
+	// Roots are certificate authorities the client certificate chain is verified against. Intermediate certificates
+	// sent by the client are used to build the chain.
+	// Optional. When nil, client certificate must have been verified during TLS handshake (see
+	// echo.StartConfig.ClientCAs) and chains verified by the handshake are used.
+	Roots *x509.CertPool
+
+	// Intermediates are additional intermediate certificates used to build the chain when Roots is set.
+	// Optional.
+	Intermediates *x509.CertPool
+
+	// ExtKeyUsages are extended key usages client certificate must be valid for. Certificate is valid when it is valid
+	// for any of the usages.
+	// Optional. Default value []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}.
+	ExtKeyUsages []x509.ExtKeyUsage
+
+	// AllowedDNSNames are DNS name SANs of allowed clients. Name with `*.` prefix allows all subdomains of the domain.
+	// When AllowedDNSNames or AllowedSPIFFEIDs is set, client certificate must have at least one of the allowed SANs.
+	// Optional.
+	AllowedDNSNames []string
+
+	// AllowedSPIFFEIDs are SPIFFE IDs (`spiffe://<trust-domain>/<path>` URI SANs) of allowed clients. ID with `/*`
+	// suffix allows all IDs under the path (i.e. `spiffe://example.org/ns/prod/*`).
+	// When AllowedDNSNames or AllowedSPIFFEIDs is set, client certificate must have at least one of the allowed SANs.
+	// Optional.
+	AllowedSPIFFEIDs []string
+
+	// CRLFile is path to PEM or DER encoded certificate revocation list (CRL). Certificates in the chain that are
+	// listed in the CRL of their issuer are rejected. CRL must be signed by the issuer of the certificate in the
+	// verified chain and must not be past its next update time, otherwise requests are rejected.
+	// Optional.
+	CRLFile string
+	// CRLReloadInterval is how often CRLFile is checked for changes while requests are handled. Changed file is read
+	// and replaces the current CRL, the current CRL is kept in use when the new one can not be read.
+	// Optional. Default value 1 minute.
+	CRLReloadInterval time.Duration
+
+	// ContextKey is the key used to store *ClientIdentity of the client in Context. ClientIdentityFrom can be used
+	// only with the default key.
+	// Optional. Default value ClientIdentityContextKey.
+	ContextKey string
+
+	// ErrorHandler defines a function which is executed when client certificate is missing or invalid.
+	// Optional. By default, error is returned with status 401 for missing or unverified certificate and 403 for
+	// certificate that is not allowed.
+	ErrorHandler func(c *echo.Context, err error) error
+}
+
+// ClientIdentity is identity of the client authenticated with client certificate.
+type ClientIdentity struct {
+	// Certificate is the client (leaf) certificate.
+	Certificate *x509.Certificate
+	// Chain is the verified certificate chain from client certificate to the root certificate authority.
+	Chain []*x509.Certificate
+
+	// CommonName is the common name of the certificate subject.
+	CommonName string
+	// DNSNames are DNS name SANs of the certificate.
+	DNSNames []string
+	// EmailAddresses are email address SANs of the certificate.
+	EmailAddresses []string
+	// URIs are URI SANs of the certificate.
+	URIs []*url.URL
+	// SPIFFEID is the SPIFFE ID of the client (URI SAN with `spiffe` scheme) or empty string.
+	SPIFFEID string
+}
+
+// ClientIdentityContextKey is the default key ClientCert middleware stores *ClientIdentity of the client in Context.
+const ClientIdentityContextKey = "client_identity"
+
+// defaultCRLReloadInterval is default value of ClientCertConfig.CRLReloadInterval.
+const defaultCRLReloadInterval = 1 * time.Minute
+
+var (
+	// ErrClientCertMissing denotes an error raised when request has no client certificate.
+	ErrClientCertMissing = echo.NewHTTPError(http.StatusUnauthorized, "missing client certificate")
+	// ErrClientCertInvalid denotes an error raised when client certificate chain can not be verified.
+	ErrClientCertInvalid = echo.NewHTTPError(http.StatusUnauthorized, "invalid client certificate")
+	// ErrClientCertNotAllowed denotes an error raised when client certificate is valid but not allowed.
+	ErrClientCertNotAllowed = echo.NewHTTPError(http.StatusForbidden, "client certificate not allowed")
+)
+
+// DefaultClientCertConfig is the default ClientCert middleware config.
+var DefaultClientCertConfig = ClientCertConfig{
+	Skipper:           DefaultSkipper,
+	ExtKeyUsages:      []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	CRLReloadInterval: defaultCRLReloadInterval,
+	ContextKey:        ClientIdentityContextKey,
+}
+
+// ClientCert returns a ClientCert middleware that requires client certificate verified during TLS handshake and
+// stores *ClientIdentity of the client in Context.
+//
+// Example:
+//
+//	g := e.Group("/internal", middleware.ClientCert())
+//	g.GET("/jobs", func(c *echo.Context) error {
+//		identity, ok := middleware.ClientIdentityFrom(c)
+//		if !ok {
+//			return echo.ErrUnauthorized
+//		}
+//		return c.String(http.StatusOK, identity.SPIFFEID)
+//	})
+func ClientCert() echo.MiddlewareFunc {
+	return ClientCertWithConfig(DefaultClientCertConfig)
+}
+
+// ClientIdentityFrom returns identity of the client stored in Context by ClientCert middleware with the default
+// ContextKey. Returns false when there is no identity in Context.
+func ClientIdentityFrom(c *echo.Context) (*ClientIdentity, bool) {
+	identity, err := echo.ContextGet[*ClientIdentity](c, ClientIdentityContextKey)
+	return identity, err == nil && identity != nil
+}
+
+// ClientCertWithConfig returns a ClientCert middleware or panics if configuration is invalid.
+//
+// Example, allow only the billing service of the production namespace:
+//
+//	e.POST("/invoices", createInvoice, middleware.ClientCertWithConfig(middleware.ClientCertConfig{
+//		AllowedSPIFFEIDs: []string{"spiffe://example.org/ns/prod/sa/billing"},
+//		CRLFile:          "/etc/pki/crl.pem",
+//	}))
+func ClientCertWithConfig(config ClientCertConfig) echo.MiddlewareFunc {
+	return toMiddlewareOrPanic(config)
+}
+
+// ToMiddleware converts ClientCertConfig to middleware or returns an error for invalid configuration
+func (config ClientCertConfig) ToMiddleware() (echo.MiddlewareFunc, error) {
+	if config.Skipper == nil {
+		config.Skipper = DefaultClientCertConfig.Skipper
+	}
+	if len(config.ExtKeyUsages) == 0 {
+		config.ExtKeyUsages = DefaultClientCertConfig.ExtKeyUsages
+	}
+	if config.ContextKey == "" {
+		config.ContextKey = DefaultClientCertConfig.ContextKey
+	}
+	if config.CRLReloadInterval <= 0 {
+		config.CRLReloadInterval = DefaultClientCertConfig.CRLReloadInterval
+	}
+	for _, id := range config.AllowedSPIFFEIDs {
+		if !strings.HasPrefix(id, "spiffe://") {
+			return nil, fmt.Errorf("echo client-cert middleware allowed SPIFFE ID %q must start with spiffe://", id)
+		}
+	}
+	var crl *revocationList
+	if config.CRLFile != "" {
+		var err error
+		if crl, err = newRevocationList(config.CRLFile, config.CRLReloadInterval); err != nil {
+			return nil, fmt.Errorf("echo client-cert middleware could not read CRL file: %w", err)
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c *echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			var crlState *revocationListState
+			if crl != nil {
+				var err error
+				if crlState, err = crl.current(time.Now()); err != nil {
+					c.Logger().Error("failed to reload CRL file, using previous CRL", "crl_file", config.CRLFile, "error", err)
+				}
+			}
+			identity, err := config.verify(c.Request(), crlState)
+			if err != nil {
+				if config.ErrorHandler != nil {
+					return config.ErrorHandler(c, err)
+				}
+				return err
+			}
+			c.Set(config.ContextKey, identity)
+			return next(c)
+		}
+	}, nil
+}
+
+func (config ClientCertConfig) verify(r *http.Request, crl *revocationListState) (*ClientIdentity, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil, ErrClientCertMissing
+	}
+	leaf := r.TLS.PeerCertificates[0]
+
+	var chains [][]*x509.Certificate
+	if config.Roots != nil {
+		intermediates := x509.NewCertPool()
+		if config.Intermediates != nil {
+			intermediates = config.Intermediates.Clone()
+		}
+		for _, cert := range r.TLS.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+		var err error
+		chains, err = leaf.Verify(x509.VerifyOptions{
+			Roots:         config.Roots,
+			Intermediates: intermediates,
+			KeyUsages:     config.ExtKeyUsages,
+		})
+		if err != nil {
+			return nil, ErrClientCertInvalid.Wrap(err)
+		}
+	} else {
+		chains = r.TLS.VerifiedChains
+		if len(chains) == 0 {
+			return nil, ErrClientCertInvalid.Wrap(errors.New("client certificate was not verified during TLS handshake"))
+		}
+		if !hasExtKeyUsage(leaf, config.ExtKeyUsages) {
+			return nil, ErrClientCertInvalid.Wrap(errors.New("client certificate is not valid for required extended key usages"))
+		}
+	}
+	chain := chains[0]
+
+	if crl != nil {
+		if err := crl.checkChain(chain, time.Now()); err != nil {
+			return nil, ErrClientCertNotAllowed.Wrap(err)
+		}
+	}
+
+	identity := &ClientIdentity{
+		Certificate:    leaf,
+		Chain:          chain,
+		CommonName:     leaf.Subject.CommonName,
+		DNSNames:       leaf.DNSNames,
+		EmailAddresses: leaf.EmailAddresses,
+		URIs:           leaf.URIs,
+	}
+	for _, u := range leaf.URIs {
+		if u.Scheme == "spiffe" {
+			identity.SPIFFEID = u.String()
+			break
+		}
+	}
+	if (len(config.AllowedDNSNames) > 0 || len(config.AllowedSPIFFEIDs) > 0) && !config.isAllowed(identity) {
+		return nil, ErrClientCertNotAllowed
+	}
+	return identity, nil
+}
+
+func (config ClientCertConfig) isAllowed(identity *ClientIdentity) bool {
+	for _, allowed := range config.AllowedDNSNames {
+		for _, name := range identity.DNSNames {
+			if matchDNSName(allowed, name) {
+				return true
+			}
+		}
+	}
+	if identity.SPIFFEID != "" {
+		for _, allowed := range config.AllowedSPIFFEIDs {
+			if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+				if strings.HasPrefix(identity.SPIFFEID, prefix+"/") {
+					return true
+				}
+			} else if identity.SPIFFEID == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchDNSName(pattern string, name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	pattern = strings.ToLower(pattern)
+	if domain, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(name, "."+domain)
+	}
+	return name == pattern
+}
+
+// hasExtKeyUsage reports whether certificate is valid for any of the usages. Certificate without extended key usages
+// is valid for any usage.
+func hasExtKeyUsage(cert *x509.Certificate, usages []x509.ExtKeyUsage) bool {
+	if len(cert.ExtKeyUsage) == 0 || slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageAny) {
+		return true
+	}
+	for _, usage := range usages {
+		if usage == x509.ExtKeyUsageAny || slices.Contains(cert.ExtKeyUsage, usage) {
+			return true
+		}
+	}
+	return false
+}
+
+// revocationList is CRL read from a file. The file is checked for changes at most once per interval and is read again
+// when its modification time or size has changed.
+type revocationList struct {
+	file     string
+	interval time.Duration
+
+	mu    sync.Mutex // serializes reloads
+	state atomic.Pointer[revocationListState]
+}
+
+type revocationListState struct {
+	crl     *x509.RevocationList
+	modTime time.Time
+	size    int64
+	checked time.Time
+	// verified holds raw issuer certificates CRL signature has been verified with, so signature is not verified for
+	// every request.
+	verified sync.Map
+}
+
+func newRevocationList(file string, interval time.Duration) (*revocationList, error) {
+	rl := &revocationList{file: file, interval: interval}
+	state, err := rl.load(nil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	rl.state.Store(state)
+	return rl, nil
+}
+
+// current returns current CRL. CRL is reloaded when the file was last checked more than interval ago and has changed
+// since. Reload error is returned together with the previous CRL.
+func (rl *revocationList) current(now time.Time) (*revocationListState, error) {
+	state := rl.state.Load()
+	if now.Sub(state.checked) < rl.interval || !rl.mu.TryLock() {
+		return state, nil // other request is already reloading, use the current CRL meanwhile
+	}
+	defer rl.mu.Unlock()
+
+	state = rl.state.Load()
+	newState, err := rl.load(state, now)
+	if err != nil {
+		// file is not checked again before next interval
+		rl.state.Store(&revocationListState{crl: state.crl, modTime: state.modTime, size: state.size, checked: now})
+		return state, err
+	}
+	rl.state.Store(newState)
+	return newState, nil
+}
+
+// load reads CRL from the file when its modification time or size differs from previous state.
+func (rl *revocationList) load(previous *revocationListState, now time.Time) (*revocationListState, error) {
+	info, err := os.Stat(rl.file)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && info.ModTime().Equal(previous.modTime) && info.Size() == previous.size {
+		return &revocationListState{crl: previous.crl, modTime: previous.modTime, size: previous.size, checked: now}, nil
+	}
+	crl, err := readRevocationList(rl.file)
+	if err != nil {
+		return nil, err
+	}
+	return &revocationListState{crl: crl, modTime: info.ModTime(), size: info.Size(), checked: now}, nil
+}
+
+// checkChain checks that certificates of the verified chain, that were issued by the CRL issuer, are not revoked. CRL
+// signature is verified with the issuer certificate of the chain and CRL must not be past its next update time.
+func (s *revocationListState) checkChain(chain []*x509.Certificate, now time.Time) error {
+	for i, cert := range chain[:len(chain)-1] { // root is not revoked by CRL
+		if !bytes.Equal(cert.RawIssuer, s.crl.RawIssuer) {
+			continue
+		}
+		issuer := chain[i+1]
+		if _, ok := s.verified.Load(string(issuer.Raw)); !ok {
+			if err := s.crl.CheckSignatureFrom(issuer); err != nil {
+				return fmt.Errorf("CRL is not signed by certificate issuer: %w", err)
+			}
+			s.verified.Store(string(issuer.Raw), struct{}{})
+		}
+		if !s.crl.NextUpdate.IsZero() && now.After(s.crl.NextUpdate) {
+			return fmt.Errorf("CRL is stale, next update was at %s", s.crl.NextUpdate.Format(time.RFC3339))
+		}
+		if isRevoked(cert, s.crl) {
+			return fmt.Errorf("certificate with serial number %s is revoked", cert.SerialNumber)
+		}
+	}
+	return nil
+}
+
+func isRevoked(cert *x509.Certificate, crl *x509.RevocationList) bool {
+	if !bytes.Equal(cert.RawIssuer, crl.RawIssuer) {
+		return false
+	}
+	for _, revoked := range crl.RevokedCertificateEntries {
+		if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func readRevocationList(file string) (*x509.RevocationList, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "X509 CRL" {
+			return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+		}
+		data = block.Bytes
+	}
+	return x509.ParseRevocationList(data)
+}
@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificateAuthority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCertificateAuthority(t *testing.T, name string) *testCertificateAuthority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCertificateAuthority{cert: cert, key: key}
+}
+
+func (ca *testCertificateAuthority) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func (ca *testCertificateAuthority) issue(t *testing.T, serial int64, template *x509.Certificate) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(serial)
+	template.NotBefore = time.Now().Add(-1 * time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	if template.ExtKeyUsage == nil {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func (ca *testCertificateAuthority) writeCRL(t *testing.T, file string, nextUpdate time.Time, revokedSerials ...int64) string {
+	var revoked []x509.RevocationListEntry
+	for _, serial := range revokedSerials {
+		revoked = append(revoked, x509.RevocationListEntry{SerialNumber: big.NewInt(serial), RevocationTime: time.Now().Add(-1 * time.Minute)})
+	}
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(time.Now().UnixNano()),
+		ThisUpdate:                time.Now().Add(-1 * time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: revoked,
+	}, ca.cert, ca.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crlDER}), 0o600))
+	return file
+}
+
+func TestClientCertWithConfig(t *testing.T) {
+	ca := newTestCertificateAuthority(t, "Test CA")
+	otherCA := newTestCertificateAuthority(t, "Other CA")
+	billingID, _ := url.Parse("spiffe://example.org/ns/prod/sa/billing")
+	billing := ca.issue(t, 10, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "billing"},
+		DNSNames: []string{"billing.prod.svc"},
+		URIs:     []*url.URL{billingID},
+	})
+	serverOnly := ca.issue(t, 11, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "web"},
+		DNSNames:    []string{"web.prod.svc"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	untrusted := otherCA.issue(t, 12, &x509.Certificate{Subject: pkix.Name{CommonName: "intruder"}})
+
+	dir := t.TempDir()
+	crlFile := ca.writeCRL(t, filepath.Join(dir, "crl.pem"), time.Now().Add(time.Hour), 10)
+	staleCRLFile := ca.writeCRL(t, filepath.Join(dir, "stale.pem"), time.Now().Add(-1*time.Minute))
+	// CA with the same name as the issuer of client certificates
+	forgedCRLFile := newTestCertificateAuthority(t, "Test CA").writeCRL(t, filepath.Join(dir, "forged.pem"), time.Now().Add(time.Hour))
+
+	var testCases = []struct {
+		name             string
+		givenConfig      ClientCertConfig
+		givenTLS         *tls.ConnectionState
+		expectIdentityCN string
+		expectSPIFFEID   string
+		expectError      string
+	}{
+		{
+			name:             "ok, verified during handshake",
+			givenTLS:         &tls.ConnectionState{PeerCertificates: []*x509.Certificate{billing}, VerifiedChains: [][]*x509.Certificate{{billing, ca.cert}}},
+			expectIdentityCN: "billing",
+			expectSPIFFEID:   "spiffe://example.org/ns/prod/sa/billing",
+		},
+		{
+			name:             "ok, verified against roots",
+			givenConfig:      ClientCertConfig{Roots: ca.pool()},
+			givenTLS:         &tls.ConnectionState{PeerCertificates: []*x509.Certificate{billing}},
+			expectIdentityCN: "billing",
+			expectSPIFFEID:   "spiffe://example.org/ns/prod/sa/billing",
+		},
+		{
+			name:             "ok, allowed SPIFFE ID prefix",
+			givenConfig:      ClientCertConfig{Roots: ca.pool(), AllowedSPIFFEIDs: []string{"spiffe://example.org/ns/prod/*"}},
+			givenTLS:         &tls.ConnectionState{PeerCertificates: []*x509.Certificate{billing}},
+			expectIdentityCN: "billing",
+			expectSPIFFEID:   "spiffe://example.org/ns/prod/sa/billing",
+		},
+		{
+			name:             "ok, allowed DNS name wildcard and server certificate allowed by EKU",
+			givenConfig:      ClientCertConfig{Roots: ca.pool(), AllowedDNSNames: []string{"*.prod.svc"}, ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}},
+			givenTLS:         &tls.ConnectionState{PeerCertificates: []*x509.Certificate{serverOnly}},
+			expectIdentityCN: "web",
+		},
+		{
+			name:        "nok, no TLS",
+			expectError: "code=401, message=missing client certificate",
+		},
+		{
+			name:        "nok, no client certificate",
+			givenTLS:    &tls.ConnectionState{},
+			expectError: "code=401, message=missing client certificate",
+		},
+		{
+			name:        "nok, not verified during handshake",
+			givenTLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{billing}},
+			expectError: "code=401, message=invalid client certificate, err=client certificate was not verified during TLS handshake",
+		},
+		{
+			name:        "nok, signed by unknown authority",
+			givenConfig: ClientCertConfig{Roots: ca.pool()},
+			givenTLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{untrusted}},
+			expectError: `code=401, message=invalid client certificate, err=x509: certificate signed by unknown authority`,
+		},
+		{
+			name:        "nok, extended key usage",
+			givenConfig: ClientCertConfig{Roots: ca.pool()},
+			givenTLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{serverOnly}},
+			expectError: "code=401, message=invalid client certificate, err=x509: certificate specifies an incompatible key usage",
+		},
+		{
+			name:        "nok, extended key usage of handshake verified certificate",
+			givenTLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{serverOnly}, VerifiedChains: [][]*x509.Certificate{{serverOnly, ca.cert}}},
+			expectError: "code=401, message=invalid client certificate, err=client certificate is not valid for required extended key usages",
+		},
+		{
+			name:        "nok, SPIFFE ID not allowed",
+			givenConfig: ClientCertConfig{Roots: ca.pool(), AllowedSPIFFEIDs: []string{"spiffe://example.org/ns/dev/*", "spiffe://example.org/ns/prod/sa/web"}},
+			givenTLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{billing}},
+			expectError: "code=403, message=client certificate not allowed",
+		},
+		{
+			name:        "nok, revoked",
+			givenConfig: ClientCertConfig{Roots: ca.pool(), CRLFile: crlFile},
+			givenTLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{billing}},
+			expectError: "code=403, message=client certificate not allowed, err=certificate with serial number 10 is revoked",
+		},
+		{
+			name:        "nok, CRL is not signed by the issuer",
+			givenConfig: ClientCertConfig{Roots: ca.pool(), CRLFile: forgedCRLFile},
+			givenTLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{billing}},
+			expectError: "code=403, message=client certificate not allowed, err=CRL is not signed by certificate issuer: x509: ECDSA verification failure",
+		},
+		{
+			name:        "nok, CRL is stale",
+			givenConfig: ClientCertConfig{Roots: ca.pool(), CRLFile: staleCRLFile},
+			givenTLS:    &tls.ConnectionState{PeerCertificates: []*x509.Certificate{billing}},
+			expectError: "code=403, message=client certificate not allowed, err=CRL is stale, next update was at ",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.TLS = tc.givenTLS
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			var identity *ClientIdentity
+			mw, err := tc.givenConfig.ToMiddleware()
+			require.NoError(t, err)
+			err = mw(func(c *echo.Context) error {
+				identity, _ = ClientIdentityFrom(c)
+				return nil
+			})(c)
+
+			if tc.expectError != "" {
+				assert.ErrorContains(t, err, tc.expectError)
+				assert.Nil(t, identity)
+				return
+			}
+			assert.NoError(t, err)
+			if assert.NotNil(t, identity) {
+				assert.Equal(t, tc.expectIdentityCN, identity.CommonName)
+				assert.Equal(t, tc.expectSPIFFEID, identity.SPIFFEID)
+				assert.Equal(t, ca.cert, identity.Chain[len(identity.Chain)-1])
+			}
+		})
+	}
+}
+
+func TestClientCert_CRLReload(t *testing.T) {
+	ca := newTestCertificateAuthority(t, "Test CA")
+	client := ca.issue(t, 10, &x509.Certificate{Subject: pkix.Name{CommonName: "billing"}})
+	crlFile := ca.writeCRL(t, filepath.Join(t.TempDir(), "crl.pem"), time.Now().Add(time.Hour))
+
+	mw, err := ClientCertConfig{Roots: ca.pool(), CRLFile: crlFile, CRLReloadInterval: time.Nanosecond}.ToMiddleware()
+	require.NoError(t, err)
+	h := mw(func(c *echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	serve := func() error {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{client}}
+		return h(echo.New().NewContext(req, httptest.NewRecorder()))
+	}
+	assert.NoError(t, serve())
+
+	// revoked after middleware was created
+	ca.writeCRL(t, crlFile, time.Now().Add(time.Hour), 10)
+	require.NoError(t, os.Chtimes(crlFile, time.Now(), time.Now().Add(time.Minute)))
+	assert.EqualError(t, serve(), "code=403, message=client certificate not allowed, err=certificate with serial number 10 is revoked")
+
+	// invalid file does not replace the current CRL
+	require.NoError(t, os.WriteFile(crlFile, []byte("invalid"), 0o600))
+	require.NoError(t, os.Chtimes(crlFile, time.Now(), time.Now().Add(2*time.Minute)))
+	assert.EqualError(t, serve(), "code=403, message=client certificate not allowed, err=certificate with serial number 10 is revoked")
+}
+
+func TestClientIdentityFrom(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	_, ok := ClientIdentityFrom(c)
+	assert.False(t, ok)
+
+	identity := &ClientIdentity{CommonName: "billing"}
+	c.Set(ClientIdentityContextKey, identity)
+	result, ok := ClientIdentityFrom(c)
+	assert.True(t, ok)
+	assert.Same(t, identity, result)
+}
+
+func TestClientCertConfig_ToMiddleware_error(t *testing.T) {
+	_, err := ClientCertConfig{AllowedSPIFFEIDs: []string{"example.org/billing"}}.ToMiddleware()
+	assert.EqualError(t, err, `echo client-cert middleware allowed SPIFFE ID "example.org/billing" must start with spiffe://`)
+
+	_, err = ClientCertConfig{CRLFile: filepath.Join(t.TempDir(), "missing.crl")}.ToMiddleware()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestClientCert_errorHandler(t *testing.T) {
+	e := echo.New()
+	e.GET("/", func(c *echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	}, ClientCertWithConfig(ClientCertConfig{
+		ErrorHandler: func(c *echo.Context, err error) error {
+			return c.String(http.StatusTeapot, err.Error())
+		},
+	}))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "code=401, message=missing client certificate", rec.Body.String())
+}
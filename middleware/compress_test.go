@@ -128,6 +128,33 @@ func TestGzip_chunked(t *testing.T) {
 	assert.Equal(t, "first\nsecond\nthird", buf.String())
 }
 
+func TestGzip_SSE(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAcceptEncoding, gzipScheme)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	h := GzipWithConfig(GzipConfig{MinLength: 1024})(func(c *echo.Context) error {
+		return c.SSEWithConfig(echo.SSEConfig{KeepAliveInterval: -1}, func(w *echo.SSEWriter) error {
+			return w.Send(echo.SSEEvent{Event: "update", Data: "first"})
+		})
+	})
+
+	err := h(c)
+	assert.NoError(t, err)
+
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, gzipScheme, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, echo.MIMETextEventStream, rec.Header().Get(echo.HeaderContentType))
+
+	r, err := gzip.NewReader(rec.Body)
+	assert.NoError(t, err)
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(r)
+	assert.Equal(t, "event: update\ndata: first\n\n", buf.String())
+}
+
 func TestGzip_NoContent(t *testing.T) {
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
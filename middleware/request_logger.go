@@ -281,7 +281,7 @@ func (config RequestLoggerConfig) ToMiddleware() (echo.MiddlewareFunc, error) {
 			if err != nil && config.HandleError {
 				// When global error handler writes the error to the client the Response gets "committed". This state can be
 				// checked with `c.Response().Committed` field.
-				c.Echo().HTTPErrorHandler(c, err)
+				c.HTTPErrorHandler()(c, err)
 			}
 			res := c.Response()
 
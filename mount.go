@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// mountPoint is Echo instance mounted under path prefix with Echo.Mount.
+type mountPoint struct {
+	prefix string
+	echo   *Echo
+}
+
+// Mount mounts sub Echo instance under path prefix. Requests with path equal to the prefix or starting with
+// `prefix + "/"` are served by the sub instance with its own middlewares, router, error handler, binder, renderer etc.
+// Middlewares registered with the parent (`Use`) and given middlewares are executed before the request is passed to
+// the sub instance. Parent routes with more specific paths under the prefix have priority over the mounted instance.
+//
+// The prefix is stripped from the request URL path before the sub instance routes the request, but Context.Path and
+// Context.RouteInfo in the sub instance handlers contain the full path with the prefix. Routes of the sub instance
+// (also routes added after mounting) are included with the prefix in the parent Router().Routes(), so
+// `e.Router().Routes().Reverse(name)` works for routes of the mounted instance.
+//
+// The prefix must be a static path, it can not contain path parameters (`:`) or wildcards (`*`).
+//
+// Example: `e.Mount("/admin", adminEcho)`
+func (e *Echo) Mount(prefix string, sub *Echo, middleware ...MiddlewareFunc) {
+	if err := e.mount(prefix, sub, middleware); err != nil {
+		panic(err) // this is how `v4` handles errors. `v5` has methods to have panic-free usage
+	}
+}
+
+func (e *Echo) mount(prefix string, sub *Echo, middleware []MiddlewareFunc) error {
+	if sub == nil {
+		return errors.New("can not mount nil Echo instance")
+	}
+	if sub == e {
+		return errors.New("can not mount Echo instance to itself")
+	}
+	if strings.ContainsAny(prefix, ":*") {
+		return errors.New("mount prefix can not contain path parameters or wildcards")
+	}
+	prefix = strings.TrimRight(prefix, "/")
+	if prefix != "" && prefix[0] != '/' {
+		prefix = "/" + prefix
+	}
+
+	mp := &mountPoint{prefix: prefix, echo: sub}
+	paths := []string{prefix + "/*"}
+	if prefix != "" {
+		paths = append(paths, prefix)
+	}
+	for _, path := range paths {
+		_, err := e.add(Route{
+			Method:      RouteAny,
+			Path:        path,
+			Name:        "mount:" + prefix,
+			Handler:     mp.handler,
+			Middlewares: middleware,
+			mount:       mp,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handler passes request with prefix stripped from the URL path to the mounted Echo instance.
+func (mp *mountPoint) handler(c *Context) error {
+	req := c.Request()
+	u := *req.URL
+	u.Path = mp.stripPrefix(u.Path)
+	if u.RawPath != "" {
+		if strings.HasPrefix(u.RawPath, mp.prefix) {
+			u.RawPath = mp.stripPrefix(u.RawPath)
+		} else {
+			u.RawPath = "" // prefix is escaped differently in raw path. let URL.EscapedPath() to recreate it
+		}
+	}
+	r := req.WithContext(req.Context())
+	r.URL = &u
+
+	// prefix of the Echo instance this mount point belongs to is already stripped when mounts are nested
+	mp.echo.serve(c.Response(), r, c.mountPrefix+mp.prefix)
+	return nil
+}
+
+func (mp *mountPoint) stripPrefix(path string) string {
+	path = strings.TrimPrefix(path, mp.prefix)
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// appendRoutes appends routes to dst replacing routes added with Echo.Mount with the routes of the mounted instance.
+func appendRoutes(dst Routes, routes Routes) Routes {
+	var mounts []*mountPoint
+	for _, ri := range routes {
+		if ri.mount == nil {
+			dst = append(dst, ri)
+			continue
+		}
+		if slices.Contains(mounts, ri.mount) {
+			continue
+		}
+		mounts = append(mounts, ri.mount)
+		for _, sri := range ri.mount.echo.Router().Routes() {
+			sri.Path = ri.mount.prefix + sri.Path
+			dst = append(dst, sri)
+		}
+	}
+	return dst
+}
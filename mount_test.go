@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEcho_Mount(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		whenURL      string
+		expectCode   int
+		expectBody   string
+		expectHeader string
+	}{
+		{
+			name:         "ok, mounted route",
+			whenURL:      "/admin/users/1",
+			expectCode:   http.StatusOK,
+			expectBody:   "path=/admin/users/:id route=/admin/users/:id url=/users/1 id=1",
+			expectHeader: "parent",
+		},
+		{
+			name:         "ok, prefix is routed to mounted root",
+			whenURL:      "/admin",
+			expectCode:   http.StatusOK,
+			expectBody:   "admin root /",
+			expectHeader: "parent",
+		},
+		{
+			name:         "ok, prefix with slash is routed to mounted root",
+			whenURL:      "/admin/",
+			expectCode:   http.StatusOK,
+			expectBody:   "admin root /",
+			expectHeader: "parent",
+		},
+		{
+			name:         "ok, parent route under prefix has priority",
+			whenURL:      "/admin/health",
+			expectCode:   http.StatusOK,
+			expectBody:   "parent health",
+			expectHeader: "parent",
+		},
+		{
+			name:         "nok, mounted instance error handler handles not found",
+			whenURL:      "/admin/unknown",
+			expectCode:   http.StatusNotFound,
+			expectBody:   "admin error: Not Found",
+			expectHeader: "parent",
+		},
+		{
+			name:         "nok, path with prefix as substring is not mounted",
+			whenURL:      "/administrator",
+			expectCode:   http.StatusNotFound,
+			expectBody:   "{\"message\":\"Not Found\"}\n",
+			expectHeader: "parent",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			admin := New()
+			admin.HTTPErrorHandler = func(c *Context, err error) {
+				_ = c.String(StatusCode(err), "admin error: "+err.Error())
+			}
+			admin.GET("/", func(c *Context) error {
+				return c.String(http.StatusOK, "admin root "+c.Request().URL.Path)
+			})
+			admin.GET("/users/:id", func(c *Context) error {
+				return c.String(http.StatusOK, "path="+c.Path()+" route="+c.RouteInfo().Path+" url="+c.Request().URL.Path+" id="+c.Param("id"))
+			})
+
+			e := New()
+			e.Use(func(next HandlerFunc) HandlerFunc {
+				return func(c *Context) error {
+					c.Response().Header().Set("X-Middleware", "parent")
+					return next(c)
+				}
+			})
+			e.GET("/admin/health", func(c *Context) error {
+				return c.String(http.StatusOK, "parent health")
+			})
+			e.Mount("/admin/", admin)
+
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+			assert.Equal(t, tc.expectHeader, rec.Header().Get("X-Middleware"))
+		})
+	}
+}
+
+func TestEcho_Mount_routes(t *testing.T) {
+	admin := New()
+	_, err := admin.AddRoute(Route{Method: http.MethodGet, Path: "/users/:id", Name: "admin.user", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	e := New()
+	e.GET("/", handlerFunc)
+	e.Mount("/admin", admin)
+
+	// routes added after mounting are included too
+	_, err = admin.AddRoute(Route{Method: http.MethodDelete, Path: "/users/:id", Name: "admin.deleteUser", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	routes := e.Router().Routes()
+	var paths []string
+	for _, r := range routes {
+		paths = append(paths, r.Method+" "+r.Path)
+	}
+	assert.Equal(t, []string{"GET /", "GET /admin/users/:id", "DELETE /admin/users/:id"}, paths)
+
+	uri, err := routes.Reverse("admin.deleteUser", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "/admin/users/42", uri)
+
+	// mounted instance routes are not changed
+	assert.Equal(t, "/users/:id", admin.Router().Routes()[0].Path)
+}
+
+func TestEcho_Mount_rawPath(t *testing.T) {
+	admin := New()
+	admin.GET("/files/:name", func(c *Context) error {
+		return c.String(http.StatusOK, c.Param("name")+" "+c.Request().URL.EscapedPath())
+	})
+	e := New()
+	e.Mount("/admin", admin)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/files/a%2Fb", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "a%2Fb /files/a%2Fb", rec.Body.String())
+}
+
+func TestEcho_Mount_nested(t *testing.T) {
+	b := New()
+	b.GET("/x", func(c *Context) error {
+		return c.String(http.StatusOK, "path="+c.Path()+" route="+c.RouteInfo().Path+" url="+c.Request().URL.Path)
+	})
+	a := New()
+	a.Mount("/b", b)
+	e := New()
+	e.Mount("/a", a)
+
+	req := httptest.NewRequest(http.MethodGet, "/a/b/x", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "path=/a/b/x route=/a/b/x url=/x", rec.Body.String())
+
+	routes := e.Router().Routes()
+	if assert.Len(t, routes, 1) {
+		assert.Equal(t, "/a/b/x", routes[0].Path)
+	}
+}
+
+func TestEcho_Mount_panics(t *testing.T) {
+	e := New()
+	assert.PanicsWithError(t, "can not mount nil Echo instance", func() {
+		e.Mount("/admin", nil)
+	})
+	assert.PanicsWithError(t, "can not mount Echo instance to itself", func() {
+		e.Mount("/admin", e)
+	})
+	assert.PanicsWithError(t, "mount prefix can not contain path parameters or wildcards", func() {
+		e.Mount("/tenants/:id", New())
+	})
+	assert.PanicsWithError(t, "mount prefix can not contain path parameters or wildcards", func() {
+		e.Mount("/static/*", New())
+	})
+}
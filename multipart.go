@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultMultipartMaxFieldSize is the default size limit of non-file part values that are read into memory by
+// MultipartPart.Value.
+const DefaultMultipartMaxFieldSize int64 = 1 << 20 // 1 MB
+
+// MultipartReaderConfig is configuration for Context.MultipartReader.
+type MultipartReaderConfig struct {
+	// MaxPartSize is the size limit of single part content in bytes. Reading part beyond the limit results
+	// http.StatusRequestEntityTooLarge error.
+	// Optional. Default value 0 (no limit).
+	MaxPartSize int64
+
+	// MaxTotalSize is the size limit of the whole request body in bytes (including part headers and boundaries).
+	// Reading body beyond the limit results http.StatusRequestEntityTooLarge error.
+	// Optional. Default value 0 (no limit).
+	MaxTotalSize int64
+
+	// MaxFieldSize is the size limit of non-file part values that are read into memory by MultipartPart.Value.
+	// Optional. Default value DefaultMultipartMaxFieldSize.
+	MaxFieldSize int64
+
+	// MaxParts is the limit of number of parts in the request. Request with more parts results
+	// http.StatusRequestEntityTooLarge error.
+	// Optional. Default value 0 (no limit).
+	MaxParts int
+
+	// AllowedContentTypes are media types that file parts are allowed to have (e.g. `image/png`). Media type can have
+	// wildcard subtype (`image/*`). File part with other content type results http.StatusUnsupportedMediaType error.
+	// Optional. Default value nil (any content type is allowed).
+	AllowedContentTypes []string
+}
+
+// MultipartReader reads `multipart/form-data` request body part by part without buffering parts to memory or
+// temporary files as Context.MultipartForm does. Parts must be read in order they appear in the request.
+//
+// Example:
+//
+//	mr, err := c.MultipartReader(echo.MultipartReaderConfig{
+//		MaxPartSize:         1 << 30,
+//		AllowedContentTypes: []string{"video/*"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for {
+//		part, err := mr.NextFile() // field parts before the file are collected for Bind
+//		if err == io.EOF {
+//			break
+//		} else if err != nil {
+//			return err
+//		}
+//		var meta UploadMeta
+//		if err := mr.Bind(&meta); err != nil {
+//			return err
+//		}
+//		if err := storage.Put(meta.Path, part.FileName(), part); err != nil {
+//			return err
+//		}
+//	}
+type MultipartReader struct {
+	reader *multipart.Reader
+	config MultipartReaderConfig
+	values url.Values
+	parts  int
+	// current is the part returned by the last NextPart call
+	current *MultipartPart
+}
+
+// MultipartPart is a single part of the multipart body. Reading the part content is limited by
+// MultipartReaderConfig.MaxPartSize. Part content can not be read after the next part is requested.
+type MultipartPart struct {
+	*multipart.Part
+	reader  *MultipartReader
+	maxSize int64
+	size    int64
+	err     error
+}
+
+// MultipartReader returns reader that streams `multipart/form-data` request body part by part. Returns
+// http.StatusUnsupportedMediaType error when request is not multipart request.
+func (c *Context) MultipartReader(config MultipartReaderConfig) (*MultipartReader, error) {
+	req := c.Request()
+	if config.MaxTotalSize > 0 {
+		req.Body = &multipartBodyReader{ReadCloser: req.Body, remaining: config.MaxTotalSize}
+	}
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return nil, ErrUnsupportedMediaType.Wrap(err)
+	}
+	if config.MaxFieldSize <= 0 {
+		config.MaxFieldSize = DefaultMultipartMaxFieldSize
+	}
+	return &MultipartReader{
+		reader: reader,
+		config: config,
+		values: url.Values{},
+	}, nil
+}
+
+// NextPart returns the next part of the body or io.EOF when there are no more parts. Unread content of the previous
+// part is skipped.
+func (r *MultipartReader) NextPart() (*MultipartPart, error) {
+	if r.current != nil && r.current.err != nil {
+		return nil, r.current.err
+	}
+	part, err := r.reader.NextPart()
+	if err != nil {
+		var hErr *HTTPError
+		if err == io.EOF || errors.As(err, &hErr) {
+			return nil, err
+		}
+		return nil, ErrBadRequest.Wrap(err)
+	}
+	r.parts++
+	if r.config.MaxParts > 0 && r.parts > r.config.MaxParts {
+		return nil, &HTTPError{Code: http.StatusRequestEntityTooLarge, Message: "multipart part limit exceeded"}
+	}
+	p := &MultipartPart{Part: part, reader: r, maxSize: r.config.MaxPartSize}
+	if p.IsFile() && !r.allowedContentType(p.ContentType()) {
+		return nil, &HTTPError{
+			Code:    http.StatusUnsupportedMediaType,
+			Message: fmt.Sprintf("content type %q of file %q is not allowed", p.ContentType(), p.FormName()),
+		}
+	}
+	r.current = p
+	return p, nil
+}
+
+// NextFile returns the next file part of the body or io.EOF when there are no more file parts. Values of non-file
+// parts before the file are read to Values so they can be bound with Bind before the file is read.
+func (r *MultipartReader) NextFile() (*MultipartPart, error) {
+	for {
+		part, err := r.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.IsFile() {
+			return part, nil
+		}
+		if _, err := part.Value(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Values returns values of non-file parts that have been read with MultipartPart.Value or NextFile.
+func (r *MultipartReader) Values() url.Values {
+	return r.values
+}
+
+// Bind binds values of non-file parts that have been read so far (see Values) to fields with `form` tag of the
+// bindable object.
+func (r *MultipartReader) Bind(target any) error {
+	if err := bindData(target, r.values, "form", nil); err != nil {
+		return wrapBindDataError(err)
+	}
+	return nil
+}
+
+func (r *MultipartReader) allowedContentType(contentType string) bool {
+	if len(r.config.AllowedContentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range r.config.AllowedContentTypes {
+		allowed = strings.ToLower(allowed)
+		if allowed == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFile reports whether part is a file part (has filename in its Content-Disposition header).
+func (p *MultipartPart) IsFile() bool {
+	return p.FileName() != ""
+}
+
+// ContentType returns Content-Type header of the part. Defaults to `text/plain` for non-file parts and
+// `application/octet-stream` for file parts as defined in RFC 7578.
+func (p *MultipartPart) ContentType() string {
+	if ct := p.Header.Get(HeaderContentType); ct != "" {
+		return ct
+	}
+	if p.IsFile() {
+		return MIMEOctetStream
+	}
+	return MIMETextPlain
+}
+
+// Read reads part content. Reading beyond MultipartReaderConfig.MaxPartSize results
+// http.StatusRequestEntityTooLarge error.
+func (p *MultipartPart) Read(b []byte) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	n, err := p.Part.Read(b)
+	p.size += int64(n)
+	if p.maxSize > 0 && p.size > p.maxSize {
+		p.err = &HTTPError{Code: http.StatusRequestEntityTooLarge, Message: "multipart part size limit exceeded"}
+		return n - int(p.size-p.maxSize), p.err
+	}
+	var hErr *HTTPError
+	if err != nil && err != io.EOF && !errors.As(err, &hErr) {
+		return n, ErrBadRequest.Wrap(err)
+	}
+	return n, err
+}
+
+// Value reads content of non-file part and adds it to MultipartReader Values. Reading value larger than
+// MultipartReaderConfig.MaxFieldSize results http.StatusRequestEntityTooLarge error.
+func (p *MultipartPart) Value() (string, error) {
+	b, err := io.ReadAll(io.LimitReader(p, p.reader.config.MaxFieldSize+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(b)) > p.reader.config.MaxFieldSize {
+		return "", &HTTPError{Code: http.StatusRequestEntityTooLarge, Message: "multipart field size limit exceeded"}
+	}
+	value := string(b)
+	if !p.IsFile() {
+		p.reader.values.Add(p.FormName(), value)
+	}
+	return value, nil
+}
+
+// multipartBodyReader limits size of the request body read by MultipartReader.
+type multipartBodyReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (r *multipartBodyReader) Read(b []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, &HTTPError{Code: http.StatusRequestEntityTooLarge, Message: "multipart body size limit exceeded"}
+	}
+	if int64(len(b)) > r.remaining+1 {
+		b = b[:r.remaining+1]
+	}
+	n, err := r.ReadCloser.Read(b)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n + int(r.remaining), &HTTPError{Code: http.StatusRequestEntityTooLarge, Message: "multipart body size limit exceeded"}
+	}
+	return n, err
+}
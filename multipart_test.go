@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type multipartTestPart struct {
+	name        string
+	fileName    string
+	contentType string
+	content     string
+}
+
+func newMultipartTestRequest(t *testing.T, parts ...multipartTestPart) *http.Request {
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+	for _, p := range parts {
+		h := textproto.MIMEHeader{}
+		disposition := `form-data; name="` + p.name + `"`
+		if p.fileName != "" {
+			disposition += `; filename="` + p.fileName + `"`
+		}
+		h.Set("Content-Disposition", disposition)
+		if p.contentType != "" {
+			h.Set(HeaderContentType, p.contentType)
+		}
+		w, err := mw.CreatePart(h)
+		assert.NoError(t, err)
+		_, err = io.WriteString(w, p.content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set(HeaderContentType, mw.FormDataContentType())
+	return req
+}
+
+func TestContext_MultipartReader(t *testing.T) {
+	req := newMultipartTestRequest(t,
+		multipartTestPart{name: "title", content: "holiday"},
+		multipartTestPart{name: "tags", content: "sea"},
+		multipartTestPart{name: "tags", content: "sun"},
+		multipartTestPart{name: "video", fileName: "a.mp4", contentType: "video/mp4", content: "first video"},
+		multipartTestPart{name: "note", content: "after first"},
+		multipartTestPart{name: "video", fileName: "b.mp4", content: "second video"},
+	)
+	c := New().NewContext(req, httptest.NewRecorder())
+
+	mr, err := c.MultipartReader(MultipartReaderConfig{})
+	assert.NoError(t, err)
+
+	part, err := mr.NextFile()
+	assert.NoError(t, err)
+	assert.Equal(t, "video", part.FormName())
+	assert.Equal(t, "a.mp4", part.FileName())
+	assert.Equal(t, "video/mp4", part.ContentType())
+	content, err := io.ReadAll(part)
+	assert.NoError(t, err)
+	assert.Equal(t, "first video", string(content))
+
+	target := struct {
+		Title string   `form:"title"`
+		Tags  []string `form:"tags"`
+	}{}
+	assert.NoError(t, mr.Bind(&target))
+	assert.Equal(t, "holiday", target.Title)
+	assert.Equal(t, []string{"sea", "sun"}, target.Tags)
+
+	part, err = mr.NextPart()
+	assert.NoError(t, err)
+	assert.False(t, part.IsFile())
+	assert.Equal(t, MIMETextPlain, part.ContentType())
+	value, err := part.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "after first", value)
+
+	part, err = mr.NextFile()
+	assert.NoError(t, err)
+	assert.Equal(t, "b.mp4", part.FileName())
+	assert.Equal(t, MIMEOctetStream, part.ContentType())
+	// unread content is skipped
+
+	_, err = mr.NextFile()
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, "after first", mr.Values().Get("note"))
+}
+
+func TestContext_MultipartReader_limits(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenConfig  MultipartReaderConfig
+		givenParts   []multipartTestPart
+		expectStatus int
+		expectError  string
+	}{
+		{
+			name:        "ok, within limits",
+			givenConfig: MultipartReaderConfig{MaxPartSize: 5, MaxTotalSize: 1024, MaxParts: 2, AllowedContentTypes: []string{"image/*"}},
+			givenParts: []multipartTestPart{
+				{name: "field", content: "12345"},
+				{name: "file", fileName: "a.png", contentType: "image/png", content: "12345"},
+			},
+		},
+		{
+			name:        "nok, part size limit",
+			givenConfig: MultipartReaderConfig{MaxPartSize: 5},
+			givenParts: []multipartTestPart{
+				{name: "file", fileName: "a.bin", content: "123456"},
+			},
+			expectStatus: http.StatusRequestEntityTooLarge,
+			expectError:  "code=413, message=multipart part size limit exceeded",
+		},
+		{
+			name:        "nok, total size limit",
+			givenConfig: MultipartReaderConfig{MaxTotalSize: 100},
+			givenParts: []multipartTestPart{
+				{name: "file", fileName: "a.bin", content: strings.Repeat("x", 200)},
+			},
+			expectStatus: http.StatusRequestEntityTooLarge,
+			expectError:  "code=413, message=multipart body size limit exceeded",
+		},
+		{
+			name:        "nok, field size limit",
+			givenConfig: MultipartReaderConfig{MaxFieldSize: 3},
+			givenParts: []multipartTestPart{
+				{name: "field", content: "1234"},
+			},
+			expectStatus: http.StatusRequestEntityTooLarge,
+			expectError:  "code=413, message=multipart field size limit exceeded",
+		},
+		{
+			name:        "nok, parts limit",
+			givenConfig: MultipartReaderConfig{MaxParts: 1},
+			givenParts: []multipartTestPart{
+				{name: "a", content: "1"},
+				{name: "b", content: "2"},
+			},
+			expectStatus: http.StatusRequestEntityTooLarge,
+			expectError:  "code=413, message=multipart part limit exceeded",
+		},
+		{
+			name:        "nok, content type not allowed",
+			givenConfig: MultipartReaderConfig{AllowedContentTypes: []string{"image/*", "application/pdf"}},
+			givenParts: []multipartTestPart{
+				{name: "file", fileName: "a.exe", contentType: "application/x-msdownload", content: "MZ"},
+			},
+			expectStatus: http.StatusUnsupportedMediaType,
+			expectError:  `code=415, message=content type "application/x-msdownload" of file "file" is not allowed`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newMultipartTestRequest(t, tc.givenParts...)
+			c := New().NewContext(req, httptest.NewRecorder())
+
+			mr, err := c.MultipartReader(tc.givenConfig)
+			assert.NoError(t, err)
+
+			for err == nil {
+				var part *MultipartPart
+				if part, err = mr.NextPart(); err != nil {
+					break
+				}
+				if part.IsFile() {
+					_, err = io.Copy(io.Discard, part)
+				} else {
+					_, err = part.Value()
+				}
+			}
+			if tc.expectError == "" {
+				assert.Equal(t, io.EOF, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectError)
+			assert.Equal(t, tc.expectStatus, StatusCode(err))
+		})
+	}
+}
+
+func TestContext_MultipartReader_notMultipart(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+	c := New().NewContext(req, httptest.NewRecorder())
+
+	_, err := c.MultipartReader(MultipartReaderConfig{})
+	assert.EqualError(t, err, "code=415, message=Unsupported Media Type, err=request Content-Type isn't multipart/form-data")
+}
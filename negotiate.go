@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ResponseEncoder encodes response body for single media type. Register encoders for media types that Echo does not
+// support out of the box (i.e. MIMEApplicationMsgpack, MIMEApplicationProtobuf) with Echo.Encoders to use them with
+// Context.Negotiate.
+type ResponseEncoder interface {
+	// Encode writes encoded target to the c.Response(). Content-Type header and status code are already set by the
+	// caller. Status code is sent to the client with the first write, so when Encode returns an error before
+	// writing, the error can still be handled by the error handler.
+	Encode(c *Context, target any) error
+}
+
+// ResponseEncoderFunc is an adapter to allow the use of ordinary functions as ResponseEncoder.
+type ResponseEncoderFunc func(c *Context, target any) error
+
+// Encode calls f(c, target).
+func (f ResponseEncoderFunc) Encode(c *Context, target any) error {
+	return f(c, target)
+}
+
+// NegotiateContentType returns the best media type from offers for the request Accept header and adds `Accept` to
+// the `Vary` response header. Offers are in server preference order - when multiple offers are equally acceptable
+// to the client, the first one is chosen. When request has no Accept header, the first offer is returned.
+//
+// Returns ErrNotAcceptable when none of the offers are acceptable.
+func (c *Context) NegotiateContentType(offers ...string) (string, error) {
+	c.addVaryHeader(HeaderAccept)
+	if len(offers) == 0 {
+		return "", ErrNotAcceptable
+	}
+	accept := c.request.Header.Values(HeaderAccept)
+	if len(accept) == 0 {
+		return offers[0], nil
+	}
+	ranges := parseAccept(accept)
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := acceptQuality(ranges, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	if best == "" {
+		return "", ErrNotAcceptable
+	}
+	return best, nil
+}
+
+// Negotiate sends data with status code using representation chosen from the request Accept header. When offers are
+// not given, JSON, XML and media types of Echo.Encoders (in alphabetical order) are offered.
+//
+// Encoders registered with Echo.Encoders take precedence over built-in JSON, XML and plain text encoding.
+// Returns ErrNotAcceptable when none of the offers are acceptable.
+func (c *Context) Negotiate(code int, data any, offers ...string) error {
+	if len(offers) == 0 {
+		offers = append([]string{MIMEApplicationJSON, MIMEApplicationXML}, slices.Sorted(maps.Keys(c.echo.Encoders))...)
+	}
+	mediaType, err := c.NegotiateContentType(offers...)
+	if err != nil {
+		return err
+	}
+
+	if enc, ok := c.echo.Encoders[mediaType]; ok {
+		return c.encode(code, mediaType, data, enc)
+	}
+	switch mediaType {
+	case MIMEApplicationJSON:
+		return c.JSON(code, data)
+	case MIMEApplicationXML, MIMETextXML:
+		return c.XML(code, data)
+	case MIMETextPlain:
+		return c.String(code, fmt.Sprint(data))
+	}
+	return fmt.Errorf("%w: %s", ErrEncoderNotRegistered, mediaType)
+}
+
+func (c *Context) encode(code int, contentType string, data any, enc ResponseEncoder) error {
+	c.writeContentType(contentType)
+
+	// delay sending status code until the first write so encoding error could be handled by the error handler
+	resp := c.Response()
+	c.SetResponse(&delayedStatusWriter{ResponseWriter: resp, status: code})
+	defer c.SetResponse(resp)
+
+	return enc.Encode(c, data)
+}
+
+func (c *Context) addVaryHeader(value string) {
+	header := c.Response().Header()
+	for _, v := range header.Values(HeaderVary) {
+		for part := range strings.SplitSeq(v, ",") {
+			if p := strings.TrimSpace(part); p == "*" || strings.EqualFold(p, value) {
+				return
+			}
+		}
+	}
+	header.Add(HeaderVary, value)
+}
+
+type acceptRange struct {
+	typ     string
+	subtype string
+	q       float64
+}
+
+// parseAccept parses Accept header values (RFC 9110 section 12.5.1). Invalid media ranges are ignored.
+func parseAccept(values []string) []acceptRange {
+	ranges := make([]acceptRange, 0, 4)
+	for _, v := range values {
+		for part := range strings.SplitSeq(v, ",") {
+			mediaRange, params, _ := strings.Cut(part, ";")
+			typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(mediaRange)), "/")
+			if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+				continue
+			}
+			ar := acceptRange{typ: typ, subtype: subtype, q: 1}
+			for param := range strings.SplitSeq(params, ";") {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "q") {
+					continue
+				}
+				q, err := strconv.ParseFloat(value, 64)
+				if err != nil || q < 0 || q > 1 {
+					q = 0
+				}
+				ar.q = q
+			}
+			ranges = append(ranges, ar)
+		}
+	}
+	return ranges
+}
+
+// acceptQuality returns quality value of the most specific media range matching the media type.
+func acceptQuality(ranges []acceptRange, mediaType string) float64 {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	typ, subtype, _ := strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), "/")
+
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		s := -1
+		switch {
+		case r.typ == typ && r.subtype == subtype:
+			s = 2
+		case r.typ == typ && r.subtype == "*":
+			s = 1
+		case r.typ == "*":
+			s = 0
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_NegotiateContentType(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		whenAccept  []string
+		whenOffers  []string
+		expect      string
+		expectError error
+	}{
+		{
+			name:       "ok, no accept header picks first offer",
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationJSON,
+		},
+		{
+			name:       "ok, exact match",
+			whenAccept: []string{MIMEApplicationXML},
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationXML,
+		},
+		{
+			name:       "ok, highest q-value wins",
+			whenAccept: []string{"application/json;q=0.5, application/xml;q=0.9"},
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationXML,
+		},
+		{
+			name:       "ok, equal q-values use offer order",
+			whenAccept: []string{"application/xml, application/json"},
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationJSON,
+		},
+		{
+			name:       "ok, multiple accept headers",
+			whenAccept: []string{"text/html;q=0.1", "application/xml"},
+			whenOffers: []string{MIMETextHTML, MIMEApplicationXML},
+			expect:     MIMEApplicationXML,
+		},
+		{
+			name:       "ok, subtype wildcard",
+			whenAccept: []string{"text/*;q=0.8, application/json;q=0.5"},
+			whenOffers: []string{MIMEApplicationJSON, MIMETextPlain},
+			expect:     MIMETextPlain,
+		},
+		{
+			name:       "ok, any wildcard",
+			whenAccept: []string{"*/*"},
+			whenOffers: []string{MIMEApplicationMsgpack, MIMEApplicationJSON},
+			expect:     MIMEApplicationMsgpack,
+		},
+		{
+			name:       "ok, more specific range overrides wildcard",
+			whenAccept: []string{"*/*;q=0.9, application/json;q=0.1"},
+			whenOffers: []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expect:     MIMEApplicationXML,
+		},
+		{
+			name:       "ok, offer with parameters",
+			whenAccept: []string{"application/json"},
+			whenOffers: []string{MIMETextPlainCharsetUTF8, MIMEApplicationJSON},
+			expect:     MIMEApplicationJSON,
+		},
+		{
+			name:       "ok, case insensitive",
+			whenAccept: []string{"Application/JSON"},
+			whenOffers: []string{MIMEApplicationXML, MIMEApplicationJSON},
+			expect:     MIMEApplicationJSON,
+		},
+		{
+			name:        "nok, q=0 excludes media type",
+			whenAccept:  []string{"application/json;q=0, */*;q=0"},
+			whenOffers:  []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expectError: ErrNotAcceptable,
+		},
+		{
+			name:        "nok, q=0 excludes media type matched by wildcard",
+			whenAccept:  []string{"application/*, application/xml;q=0"},
+			whenOffers:  []string{MIMEApplicationXML},
+			expectError: ErrNotAcceptable,
+		},
+		{
+			name:        "nok, no match",
+			whenAccept:  []string{"image/png"},
+			whenOffers:  []string{MIMEApplicationJSON, MIMEApplicationXML},
+			expectError: ErrNotAcceptable,
+		},
+		{
+			name:        "nok, no offers",
+			expectError: ErrNotAcceptable,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, a := range tc.whenAccept {
+				req.Header.Add(HeaderAccept, a)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			result, err := c.NegotiateContentType(tc.whenOffers...)
+
+			assert.Equal(t, tc.expect, result)
+			assert.Equal(t, tc.expectError, err)
+			assert.Equal(t, []string{HeaderAccept}, rec.Header().Values(HeaderVary))
+		})
+	}
+}
+
+func TestContext_NegotiateContentType_varyHeader(t *testing.T) {
+	var testCases = []struct {
+		name       string
+		givenVary  []string
+		expectVary []string
+	}{
+		{name: "ok, adds to existing", givenVary: []string{HeaderAcceptEncoding}, expectVary: []string{HeaderAcceptEncoding, HeaderAccept}},
+		{name: "ok, already present", givenVary: []string{"Origin, accept"}, expectVary: []string{"Origin, accept"}},
+		{name: "ok, wildcard", givenVary: []string{"*"}, expectVary: []string{"*"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			for _, v := range tc.givenVary {
+				rec.Header().Add(HeaderVary, v)
+			}
+
+			_, err := c.NegotiateContentType(MIMEApplicationJSON)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectVary, rec.Header().Values(HeaderVary))
+		})
+	}
+}
+
+type negotiateTestEncoder struct {
+	err error
+}
+
+func (enc negotiateTestEncoder) Encode(c *Context, target any) error {
+	if enc.err != nil {
+		return enc.err
+	}
+	_, err := fmt.Fprintf(c.Response(), "msgpack:%v", target)
+	return err
+}
+
+func TestContext_Negotiate(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		givenEncoders     map[string]ResponseEncoder
+		whenAccept        string
+		whenOffers        []string
+		expectCode        int
+		expectContentType string
+		expectBody        string
+		expectError       string
+	}{
+		{
+			name:              "ok, json by default",
+			expectCode:        http.StatusCreated,
+			expectContentType: MIMEApplicationJSON,
+			expectBody:        userJSON + "\n",
+		},
+		{
+			name:              "ok, xml",
+			whenAccept:        "application/xml",
+			expectCode:        http.StatusCreated,
+			expectContentType: MIMEApplicationXMLCharsetUTF8,
+			expectBody:        xml.Header + userXML,
+		},
+		{
+			name:              "ok, text/plain when offered",
+			whenAccept:        "text/plain",
+			whenOffers:        []string{MIMEApplicationJSON, MIMETextPlain},
+			expectCode:        http.StatusCreated,
+			expectContentType: MIMETextPlainCharsetUTF8,
+			expectBody:        "{1 Jon Snow}",
+		},
+		{
+			name:              "ok, registered encoder is offered by default",
+			givenEncoders:     map[string]ResponseEncoder{MIMEApplicationMsgpack: negotiateTestEncoder{}},
+			whenAccept:        "application/msgpack, application/json;q=0.5",
+			expectCode:        http.StatusCreated,
+			expectContentType: MIMEApplicationMsgpack,
+			expectBody:        "msgpack:{1 Jon Snow}",
+		},
+		{
+			name:              "nok, encoder error is returned before status is sent",
+			givenEncoders:     map[string]ResponseEncoder{MIMEApplicationMsgpack: negotiateTestEncoder{err: errors.New("encode")}},
+			whenAccept:        "application/msgpack",
+			expectCode:        http.StatusOK,
+			expectContentType: MIMEApplicationMsgpack,
+			expectError:       "encode",
+		},
+		{
+			name:        "nok, offer without encoder",
+			whenAccept:  "application/protobuf",
+			whenOffers:  []string{MIMEApplicationProtobuf},
+			expectCode:  http.StatusOK,
+			expectError: "encoder not registered: application/protobuf",
+		},
+		{
+			name:        "nok, not acceptable",
+			whenAccept:  "image/png",
+			expectCode:  http.StatusOK,
+			expectError: "Not Acceptable",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.Encoders = tc.givenEncoders
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.whenAccept != "" {
+				req.Header.Set(HeaderAccept, tc.whenAccept)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := c.Negotiate(http.StatusCreated, user{ID: 1, Name: "Jon Snow"}, tc.whenOffers...)
+
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectContentType, rec.Header().Get(HeaderContentType))
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+			assert.Equal(t, HeaderAccept, rec.Header().Get(HeaderVary))
+		})
+	}
+}
+
+func TestContext_Negotiate_notAcceptableResponse(t *testing.T) {
+	e := New()
+	e.GET("/", func(c *Context) error {
+		return c.Negotiate(http.StatusOK, "data")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderAccept, "text/html")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	assert.Equal(t, `{"message":"Not Acceptable"}`+"\n", rec.Body.String())
+}
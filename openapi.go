@@ -0,0 +1,809 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenAPIVersion is the OpenAPI specification version of documents generated by NewOpenAPIDocument.
+const OpenAPIVersion = "3.1.0"
+
+// MIMEApplicationYAML is the media type for YAML documents. https://www.rfc-editor.org/rfc/rfc9512
+const MIMEApplicationYAML = "application/yaml"
+
+// openAPIWildcardParamName is the name used in OpenAPI path templates for the Echo match-any (`*`) path parameter.
+// `*` itself is not allowed as a template expression name (RFC 6570 varname).
+const openAPIWildcardParamName = "wildcard"
+
+// OpenAPIOperation describes a route for the OpenAPI document generation. Set it to Route.OpenAPI when registering
+// the route with `Echo.AddRoute` or `Group.AddRoute`.
+//
+// Example:
+//
+//	e.AddRoute(echo.Route{
+//		Method:  http.MethodGet,
+//		Path:    "/users/:id",
+//		Name:    "getUser",
+//		Handler: getUser,
+//		OpenAPI: &echo.OpenAPIOperation{
+//			Summary:   "Get user by ID",
+//			Tags:      []string{"users"},
+//			Request:   GetUserRequest{},
+//			Responses: map[int]echo.OpenAPIResponse{http.StatusOK: {Body: User{}}},
+//		},
+//	})
+type OpenAPIOperation struct {
+	// OperationID is unique identifier of the operation. Defaults to the route name when route was registered with
+	// an explicit name.
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+
+	// Request is a value (or a pointer to value) of the struct the handler binds the request to. Fields with
+	// `param`, `query` and `header` tags are described as parameters. Remaining fields are described as request body
+	// using `json` tags (or `form` tags for form content types).
+	Request any
+	// RequestContentType is the media type of the request body. Defaults to MIMEApplicationJSON.
+	RequestContentType string
+
+	// Responses maps HTTP status codes to response descriptions. When empty a "default" response is documented.
+	Responses map[int]OpenAPIResponse
+}
+
+// OpenAPIResponse describes a single response of the OpenAPIOperation.
+type OpenAPIResponse struct {
+	// Description defaults to status text of the response status code.
+	Description string
+	// Body is a value (or a pointer to value) of the response body type. Leave nil for responses without body.
+	Body any
+	// ContentType is the media type of the response body. Defaults to MIMEApplicationJSON.
+	ContentType string
+}
+
+// OpenAPIConfig is the configuration for the OpenAPI document generation.
+type OpenAPIConfig struct {
+	// Info is the metadata about the API. Title and Version are required by the OpenAPI specification and
+	// default to "API" and "1.0.0" when empty.
+	Info OpenAPIInfo
+	// Servers is a list of servers providing connectivity information to the API.
+	Servers []OpenAPIServer
+	// ServerVariables describes placeholders of host routes (`{tenant}` in `{tenant}.example.com`) by name. Operations
+	// of host routes list their hosts as servers and every placeholder of these hosts must have a variable here with
+	// the default value set.
+	ServerVariables map[string]OpenAPIServerVariable
+
+	// Skipper decides if the route is left out of the document. Routes for RouteNotFound and RouteAny methods are
+	// always left out.
+	Skipper func(ri RouteInfo) bool
+}
+
+// OpenAPIDocument is the root object of the OpenAPI 3.1 document.
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Servers    []OpenAPIServer            `json:"servers,omitempty"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components *OpenAPIComponents         `json:"components,omitempty"`
+}
+
+// OpenAPIInfo is the metadata about the API.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIServer is an object representing a server.
+type OpenAPIServer struct {
+	URL         string                           `json:"url"`
+	Description string                           `json:"description,omitempty"`
+	Variables   map[string]OpenAPIServerVariable `json:"variables,omitempty"`
+}
+
+// OpenAPIServerVariable is a variable for server URL template substitution.
+type OpenAPIServerVariable struct {
+	Default     string `json:"default"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIPathItem maps lowercase HTTP methods to the operations available on a single path.
+type OpenAPIPathItem map[string]*OpenAPIOperationObject
+
+// OpenAPIOperationObject describes a single API operation on a path.
+type OpenAPIOperationObject struct {
+	OperationID string                           `json:"operationId,omitempty"`
+	Summary     string                           `json:"summary,omitempty"`
+	Description string                           `json:"description,omitempty"`
+	Tags        []string                         `json:"tags,omitempty"`
+	Deprecated  bool                             `json:"deprecated,omitempty"`
+	Parameters  []OpenAPIParameter               `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody              `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponseObject `json:"responses"`
+	// Servers lists hosts of the routes when operation is available only on specific hosts (see Route.Host).
+	Servers []OpenAPIServer `json:"servers,omitempty"`
+}
+
+// OpenAPIParameter describes a single operation parameter.
+type OpenAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *OpenAPISchema `json:"schema,omitempty"`
+}
+
+// OpenAPIRequestBody describes a single request body.
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required,omitempty"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponseObject describes a single response from an API operation.
+type OpenAPIResponseObject struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType provides schema for the media type.
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema,omitempty"`
+}
+
+// OpenAPIComponents holds reusable schemas referenced from the document.
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas,omitempty"`
+}
+
+// OpenAPISchema is a subset of JSON Schema (draft 2020-12) used by OpenAPI 3.1.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// NewOpenAPIDocument generates OpenAPI 3.1 document from given routes. Route paths are converted to OpenAPI path
+// templates (`/users/:id` becomes `/users/{id}` and the match-any `*` parameter becomes `{wildcard}`).
+// Operations of host routes (see Echo.Host) list their hosts as operation servers (`//{tenant}.example.com` with
+// placeholder as server variable from OpenAPIConfig.ServerVariables). Routes with the same method and path registered
+// for several hosts are documented as a single operation and must be described the same way.
+//
+// Use it in tests to export the document for review:
+//
+//	doc, err := echo.NewOpenAPIDocument(e.Router().Routes(), echo.OpenAPIConfig{})
+//	b, err := doc.YAML()
+func NewOpenAPIDocument(routes Routes, config OpenAPIConfig) (*OpenAPIDocument, error) {
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info:    config.Info,
+		Servers: config.Servers,
+		Paths:   map[string]OpenAPIPathItem{},
+	}
+	if doc.Info.Title == "" {
+		doc.Info.Title = "API"
+	}
+	if doc.Info.Version == "" {
+		doc.Info.Version = "1.0.0"
+	}
+
+	sg := &openAPISchemaGenerator{schemas: map[string]*OpenAPISchema{}, names: map[reflect.Type]string{}}
+	// hosts of the routes documented by the operation. Empty host means that the operation is available on any host.
+	opHosts := map[*OpenAPIOperationObject][]string{}
+	for _, ri := range routes {
+		if ri.Method == RouteNotFound || ri.Method == RouteAny {
+			continue
+		}
+		if config.Skipper != nil && config.Skipper(ri) {
+			continue
+		}
+		path, pathParams := openAPIPath(ri.Path)
+		method := strings.ToLower(ri.Method)
+
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = OpenAPIPathItem{}
+			doc.Paths[path] = item
+		}
+		if op, exists := item[method]; exists {
+			// same method and path registered for different hosts is documented by the operation of the first route
+			hosts := opHosts[op]
+			if slices.Contains(hosts, ri.Host) {
+				return nil, fmt.Errorf("openapi: duplicate operation for %s %s", ri.Method, path)
+			}
+			other, err := sg.operation(ri, pathParams)
+			if err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", ri.Method, ri.Path, err)
+			}
+			other.Servers = op.Servers
+			if !reflect.DeepEqual(op, other) {
+				return nil, fmt.Errorf("openapi: %s %s: operation for host %q differs from operation for the same method and path of other host", ri.Method, ri.Path, ri.Host)
+			}
+			opHosts[op] = append(hosts, ri.Host)
+			if err := setOpenAPIOperationServers(op, opHosts[op], config.ServerVariables); err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", ri.Method, ri.Path, err)
+			}
+			continue
+		}
+		op, err := sg.operation(ri, pathParams)
+		if err != nil {
+			return nil, fmt.Errorf("openapi: %s %s: %w", ri.Method, ri.Path, err)
+		}
+		opHosts[op] = []string{ri.Host}
+		if err := setOpenAPIOperationServers(op, opHosts[op], config.ServerVariables); err != nil {
+			return nil, fmt.Errorf("openapi: %s %s: %w", ri.Method, ri.Path, err)
+		}
+		item[method] = op
+	}
+	if len(sg.schemas) > 0 {
+		doc.Components = &OpenAPIComponents{Schemas: sg.schemas}
+	}
+	return doc, nil
+}
+
+// setOpenAPIOperationServers sets servers of the operation from hosts of its routes. Operation that is available on
+// any host has no servers of its own and inherits servers of the document.
+func setOpenAPIOperationServers(op *OpenAPIOperationObject, hosts []string, variables map[string]OpenAPIServerVariable) error {
+	op.Servers = nil
+	if slices.Contains(hosts, "") {
+		return nil
+	}
+	for _, host := range hosts {
+		hp, err := parseHostPattern(host)
+		if err != nil {
+			return err
+		}
+		// host placeholders (`{tenant}.example.com`) have the same syntax as server URL variables
+		server := OpenAPIServer{URL: "//" + hp.pattern}
+		for _, name := range hp.params {
+			if server.Variables == nil {
+				server.Variables = map[string]OpenAPIServerVariable{}
+			}
+			v, ok := variables[name]
+			if !ok || v.Default == "" {
+				return fmt.Errorf("no default for server variable %q of host %q, set it in OpenAPIConfig.ServerVariables", name, host)
+			}
+			server.Variables[name] = v
+		}
+		op.Servers = append(op.Servers, server)
+	}
+	return nil
+}
+
+// JSON returns the document encoded as indented JSON.
+func (d *OpenAPIDocument) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML returns the document encoded as YAML. Key order is the same as in the JSON encoding.
+func (d *OpenAPIDocument) YAML() ([]byte, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(b)
+}
+
+// OpenAPIHandler creates handler that serves OpenAPI document generated from all routes registered to the
+// Echo instance. Document is served as YAML when request path ends with `.yaml` or `.yml` and as JSON otherwise.
+//
+// Example: `e.GET("/openapi.json", echo.OpenAPIHandler(echo.OpenAPIConfig{Info: echo.OpenAPIInfo{Title: "My API"}}))`
+func OpenAPIHandler(config OpenAPIConfig) HandlerFunc {
+	return func(c *Context) error {
+		doc, err := NewOpenAPIDocument(c.Echo().Router().Routes(), config)
+		if err != nil {
+			return err
+		}
+		p := c.Request().URL.Path
+		if strings.HasSuffix(p, ".yaml") || strings.HasSuffix(p, ".yml") {
+			b, err := doc.YAML()
+			if err != nil {
+				return err
+			}
+			return c.Blob(http.StatusOK, MIMEApplicationYAML, b)
+		}
+		b, err := doc.JSON()
+		if err != nil {
+			return err
+		}
+		return c.JSONBlob(http.StatusOK, b)
+	}
+}
+
+// openAPIPath converts Echo route path to OpenAPI path template and returns names of path parameters in template.
+func openAPIPath(routePath string) (string, []string) {
+	var sb strings.Builder
+	params := make([]string, 0)
+	for i, l := 0, len(routePath); i < l; i++ {
+		ch := routePath[i]
+		if ch == '\\' && i+1 < l && routePath[i+1] == paramLabel {
+			sb.WriteByte(paramLabel) // escaped colon is literal colon
+			i++
+			continue
+		}
+		switch ch {
+		case paramLabel:
+			j := i + 1
+			i = paramSegmentEnd(routePath, i)
+			name, _ := splitParamConstraint(routePath[j:i])
+			params = append(params, name)
+			sb.WriteString("{" + name + "}")
+			i--
+		case anyLabel:
+			params = append(params, openAPIWildcardParamName)
+			sb.WriteString("{" + openAPIWildcardParamName + "}")
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	if sb.Len() == 0 {
+		return "/", params
+	}
+	return sb.String(), params
+}
+
+func openAPIParamConstraintSchema(constraint string) *OpenAPISchema {
+	switch constraint {
+	case "":
+		return &OpenAPISchema{Type: "string"}
+	case ParamConstraintInt, ParamConstraintUint:
+		return &OpenAPISchema{Type: "integer"}
+	case ParamConstraintAlpha:
+		return &OpenAPISchema{Type: "string", Pattern: "^[a-zA-Z]+$"}
+	case ParamConstraintAlnum:
+		return &OpenAPISchema{Type: "string", Pattern: "^[a-zA-Z0-9]+$"}
+	case ParamConstraintUUID:
+		return &OpenAPISchema{Type: "string", Format: "uuid"}
+	default:
+		return &OpenAPISchema{Type: "string", Pattern: "^(?:" + constraint + ")$"}
+	}
+}
+
+type openAPISchemaGenerator struct {
+	schemas map[string]*OpenAPISchema
+	names   map[reflect.Type]string
+}
+
+func (g *openAPISchemaGenerator) operation(ri RouteInfo, pathParams []string) (*OpenAPIOperationObject, error) {
+	op := &OpenAPIOperationObject{Responses: map[string]OpenAPIResponseObject{}}
+	if ri.Name != "" && ri.Name != ri.Method+":"+ri.Path {
+		op.OperationID = ri.Name // explicitly named routes
+	}
+
+	doc := ri.OpenAPI
+	if doc == nil {
+		doc = &OpenAPIOperation{}
+	}
+	if doc.OperationID != "" {
+		op.OperationID = doc.OperationID
+	}
+	op.Summary = doc.Summary
+	op.Description = doc.Description
+	op.Tags = doc.Tags
+	op.Deprecated = doc.Deprecated
+
+	documented := map[string]bool{}
+	if doc.Request != nil {
+		params, body, err := g.request(ri.Method, doc.Request, doc.RequestContentType)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range params {
+			if p.In == "path" {
+				documented[p.Name] = true
+			}
+		}
+		op.Parameters = params
+		op.RequestBody = body
+	}
+	// path parameters that the request struct does not describe are documented by their constraint or as strings
+	pathOnly := make([]OpenAPIParameter, 0, len(pathParams))
+	for _, name := range pathParams {
+		if documented[name] {
+			continue
+		}
+		schema := openAPIParamConstraintSchema(ri.ParamConstraints[name])
+		pathOnly = append(pathOnly, OpenAPIParameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	op.Parameters = append(pathOnly, op.Parameters...)
+
+	if len(doc.Responses) == 0 {
+		op.Responses["default"] = OpenAPIResponseObject{Description: "Default response"}
+		return op, nil
+	}
+	for code, r := range doc.Responses {
+		resp := OpenAPIResponseObject{Description: r.Description}
+		if resp.Description == "" {
+			resp.Description = http.StatusText(code)
+		}
+		if r.Body != nil {
+			schema, err := g.schema(reflect.TypeOf(r.Body), "json")
+			if err != nil {
+				return nil, err
+			}
+			ct := r.ContentType
+			if ct == "" {
+				ct = MIMEApplicationJSON
+			}
+			resp.Content = map[string]OpenAPIMediaType{ct: {Schema: schema}}
+		}
+		op.Responses[strconv.Itoa(code)] = resp
+	}
+	return op, nil
+}
+
+func (g *openAPISchemaGenerator) request(method string, request any, contentType string) ([]OpenAPIParameter, *OpenAPIRequestBody, error) {
+	typ := reflect.TypeOf(request)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, nil, errors.New("request must be a struct")
+	}
+	if contentType == "" {
+		contentType = MIMEApplicationJSON
+	}
+	bodyTag := "json"
+	if contentType == MIMEApplicationForm || contentType == MIMEMultipartForm {
+		bodyTag = "form"
+	}
+
+	params := make([]OpenAPIParameter, 0)
+	body := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+	if err := g.requestFields(typ, bodyTag, &params, body); err != nil {
+		return nil, nil, err
+	}
+
+	var requestBody *OpenAPIRequestBody
+	hasBody := method != http.MethodGet && method != http.MethodHead
+	if hasBody && len(body.Properties) > 0 {
+		requestBody = &OpenAPIRequestBody{
+			Required: true,
+			Content:  map[string]OpenAPIMediaType{contentType: {Schema: body}},
+		}
+	}
+	return params, requestBody, nil
+}
+
+func (g *openAPISchemaGenerator) requestFields(typ reflect.Type, bodyTag string, params *[]OpenAPIParameter, body *OpenAPISchema) error {
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		isParam := false
+		for _, src := range [...]struct{ tag, in string }{{"param", "path"}, {"query", "query"}, {"header", "header"}} {
+			name := f.Tag.Get(src.tag)
+			if name == "" {
+				continue
+			}
+			isParam = true
+			schema, err := g.schema(f.Type, "json")
+			if err != nil {
+				return err
+			}
+			*params = append(*params, OpenAPIParameter{Name: name, In: src.in, Required: src.in == "path", Schema: schema})
+		}
+		if isParam {
+			continue
+		}
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		name, omitempty, ok := openAPIFieldName(f, bodyTag)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if f.Anonymous && ft.Kind() == reflect.Struct {
+				if err := g.requestFields(ft, bodyTag, params, body); err != nil {
+					return err
+				}
+				continue
+			}
+			if bodyTag == "form" {
+				continue // form binding uses only explicitly tagged fields
+			}
+			name = f.Name
+		}
+		schema, err := g.schema(f.Type, bodyTag)
+		if err != nil {
+			return err
+		}
+		body.Properties[name] = schema
+		if !omitempty && f.Type.Kind() != reflect.Pointer {
+			body.Required = append(body.Required, name)
+		}
+	}
+	return nil
+}
+
+// openAPIFieldName returns the property name for struct field by given tag. Returns ok=false when field is ignored
+// (tag value `-`) and empty name when field has no explicit name.
+func openAPIFieldName(f reflect.StructField, tag string) (name string, omitempty bool, ok bool) {
+	tagValue, hasTag := f.Tag.Lookup(tag)
+	if tagValue == "-" {
+		return "", false, false
+	}
+	if !hasTag {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tagValue, ",")
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == "omitempty" || opt == "omitzero" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, true
+}
+
+var (
+	openAPITimeType      = reflect.TypeFor[time.Time]()
+	openAPIDurationType  = reflect.TypeFor[time.Duration]()
+	openAPIRawJSONType   = reflect.TypeFor[json.RawMessage]()
+	openAPISchemaNameReg = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+)
+
+func (g *openAPISchemaGenerator) schema(typ reflect.Type, tag string) (*OpenAPISchema, error) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ {
+	case openAPITimeType:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}, nil
+	case openAPIDurationType:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}, nil
+	case openAPIRawJSONType:
+		return &OpenAPISchema{}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}, nil
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}, nil
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}, nil
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}, nil
+	case reflect.Interface:
+		return &OpenAPISchema{}, nil
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}, nil // encoding/json encodes []byte as base64 string
+		}
+		items, err := g.schema(typ.Elem(), tag)
+		if err != nil {
+			return nil, err
+		}
+		return &OpenAPISchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		items, err := g.schema(typ.Elem(), tag)
+		if err != nil {
+			return nil, err
+		}
+		return &OpenAPISchema{Type: "object", AdditionalProperties: items}, nil
+	case reflect.Struct:
+		return g.structSchema(typ, tag)
+	default:
+		return nil, fmt.Errorf("unsupported type %v", typ)
+	}
+}
+
+// structSchema returns reference to named struct schema in components or inline schema for anonymous structs.
+func (g *openAPISchemaGenerator) structSchema(typ reflect.Type, tag string) (*OpenAPISchema, error) {
+	if typ.Name() == "" {
+		return g.objectSchema(typ, tag)
+	}
+	if name, ok := g.names[typ]; ok {
+		return &OpenAPISchema{Ref: "#/components/schemas/" + name}, nil
+	}
+	name := openAPISchemaNameReg.ReplaceAllString(typ.Name(), "_")
+	for i := 2; ; i++ { // different types with same name from different packages
+		if _, taken := g.schemas[name]; !taken {
+			break
+		}
+		name = fmt.Sprintf("%s%d", openAPISchemaNameReg.ReplaceAllString(typ.Name(), "_"), i)
+	}
+	g.names[typ] = name
+	g.schemas[name] = &OpenAPISchema{} // placeholder allows recursive types to reference themselves
+
+	s, err := g.objectSchema(typ, tag)
+	if err != nil {
+		return nil, err
+	}
+	g.schemas[name] = s
+	return &OpenAPISchema{Ref: "#/components/schemas/" + name}, nil
+}
+
+func (g *openAPISchemaGenerator) objectSchema(typ reflect.Type, tag string) (*OpenAPISchema, error) {
+	s := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+	if err := g.objectFields(typ, tag, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (g *openAPISchemaGenerator) objectFields(typ reflect.Type, tag string, s *OpenAPISchema) error {
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		name, omitempty, ok := openAPIFieldName(f, tag)
+		if !ok {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if name == "" {
+			if f.Anonymous && ft.Kind() == reflect.Struct {
+				if err := g.objectFields(ft, tag, s); err != nil {
+					return err
+				}
+				continue
+			}
+			name = f.Name
+		}
+		fs, err := g.schema(f.Type, tag)
+		if err != nil {
+			return err
+		}
+		s.Properties[name] = fs
+		if !omitempty && f.Type.Kind() != reflect.Pointer {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return nil
+}
+
+// jsonToYAML converts JSON document to YAML (block style) preserving order of object keys.
+func jsonToYAML(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	root, err := readYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	writeYAMLNode(buf, root, 0, false)
+	if buf.Len() == 0 || buf.Bytes()[buf.Len()-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+type yamlNode struct {
+	keys     []string    // for objects
+	children []*yamlNode // for objects and arrays
+	scalar   string      // already encoded scalar
+	kind     byte        // 'o' object, 'a' array, 's' scalar
+}
+
+func readYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := t.(type) {
+	case json.Delim:
+		n := &yamlNode{kind: 'a'}
+		if v == '{' {
+			n.kind = 'o'
+		}
+		for dec.More() {
+			if n.kind == 'o' {
+				kt, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, kt.(string))
+			}
+			child, err := readYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, child)
+		}
+		if _, err := dec.Token(); err != nil { // closing delimiter
+			return nil, err
+		}
+		return n, nil
+	case string:
+		q, _ := json.Marshal(v) // JSON string escapes are valid YAML double-quoted scalar escapes
+		return &yamlNode{kind: 's', scalar: string(q)}, nil
+	case json.Number:
+		return &yamlNode{kind: 's', scalar: v.String()}, nil
+	case bool:
+		return &yamlNode{kind: 's', scalar: strconv.FormatBool(v)}, nil
+	case nil:
+		return &yamlNode{kind: 's', scalar: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", t)
+}
+
+var yamlPlainKeyReg = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$-]*$`)
+
+// isYAMLReservedKey checks if plain key would be parsed as null or boolean. YAML 1.1 parsers also treat yes/no/on/off
+// and y/n as booleans.
+func isYAMLReservedKey(k string) bool {
+	switch strings.ToLower(k) {
+	case "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return true
+	}
+	return false
+}
+
+func writeYAMLNode(w io.Writer, n *yamlNode, indent int, inline bool) {
+	pad := strings.Repeat("  ", indent)
+	switch n.kind {
+	case 's':
+		fmt.Fprintf(w, "%s\n", n.scalar)
+	case 'o':
+		if len(n.keys) == 0 {
+			fmt.Fprint(w, "{}\n")
+			return
+		}
+		if inline {
+			fmt.Fprint(w, "\n")
+		}
+		for i, k := range n.keys {
+			if !yamlPlainKeyReg.MatchString(k) || isYAMLReservedKey(k) {
+				q, _ := json.Marshal(k)
+				k = string(q)
+			}
+			fmt.Fprintf(w, "%s%s:", pad, k)
+			child := n.children[i]
+			if child.kind == 's' || len(child.children) == 0 {
+				fmt.Fprint(w, " ")
+			}
+			writeYAMLNode(w, child, indent+1, true)
+		}
+	case 'a':
+		if len(n.children) == 0 {
+			fmt.Fprint(w, "[]\n")
+			return
+		}
+		if inline {
+			fmt.Fprint(w, "\n")
+		}
+		for _, child := range n.children {
+			fmt.Fprintf(w, "%s-", pad)
+			if child.kind == 's' || len(child.children) == 0 {
+				fmt.Fprint(w, " ")
+				writeYAMLNode(w, child, indent+1, true)
+				continue
+			}
+			// nested collection inside sequence entry is written on following lines with deeper indentation
+			writeYAMLNode(w, child, indent+1, true)
+		}
+	}
+}
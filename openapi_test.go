@@ -0,0 +1,407 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type openAPITestUser struct {
+	ID        int64             `json:"id"`
+	Name      string            `json:"name"`
+	Email     *string           `json:"email,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	Friends   []openAPITestUser `json:"friends,omitempty"`
+	internal  string
+}
+
+type openAPITestUpdateUser struct {
+	ID      int64  `param:"id"`
+	DryRun  bool   `query:"dry_run"`
+	TraceID string `header:"X-Trace-Id"`
+	Name    string `json:"name"`
+	Ignored string `json:"-"`
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	var testCases = []struct {
+		whenPath     string
+		expectPath   string
+		expectParams []string
+	}{
+		{whenPath: "", expectPath: "/", expectParams: []string{}},
+		{whenPath: "/users/:id", expectPath: "/users/{id}", expectParams: []string{"id"}},
+		{whenPath: "/users/:id/files/*", expectPath: "/users/{id}/files/{wildcard}", expectParams: []string{"id", "wildcard"}},
+		{whenPath: "/v1/users\\:search", expectPath: "/v1/users:search", expectParams: []string{}},
+		{whenPath: "/a/:b/c/:d", expectPath: "/a/{b}/c/{d}", expectParams: []string{"b", "d"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.whenPath, func(t *testing.T) {
+			path, params := openAPIPath(tc.whenPath)
+			assert.Equal(t, tc.expectPath, path)
+			assert.Equal(t, tc.expectParams, params)
+		})
+	}
+}
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	e := New()
+	_, err := e.AddRoute(Route{
+		Method:  http.MethodPut,
+		Path:    "/users/:id",
+		Name:    "updateUser",
+		Handler: handlerFunc,
+		OpenAPI: &OpenAPIOperation{
+			Summary: "Update user",
+			Tags:    []string{"users"},
+			Request: &openAPITestUpdateUser{},
+			Responses: map[int]OpenAPIResponse{
+				http.StatusOK:       {Body: openAPITestUser{}},
+				http.StatusNotFound: {},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	e.GET("/users/:id/files/*", handlerFunc)
+	e.RouteNotFound("/*", handlerFunc)
+
+	doc, err := NewOpenAPIDocument(e.Router().Routes(), OpenAPIConfig{Info: OpenAPIInfo{Title: "Test"}})
+	assert.NoError(t, err)
+
+	b, err := doc.JSON()
+	assert.NoError(t, err)
+
+	expect := `{
+  "openapi": "3.1.0",
+  "info": {
+    "title": "Test",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/users/{id}": {
+      "put": {
+        "operationId": "updateUser",
+        "summary": "Update user",
+        "tags": [
+          "users"
+        ],
+        "parameters": [
+          {
+            "name": "id",
+            "in": "path",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "format": "int64"
+            }
+          },
+          {
+            "name": "dry_run",
+            "in": "query",
+            "schema": {
+              "type": "boolean"
+            }
+          },
+          {
+            "name": "X-Trace-Id",
+            "in": "header",
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "name": {
+                    "type": "string"
+                  }
+                },
+                "required": [
+                  "name"
+                ]
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/openAPITestUser"
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Not Found"
+          }
+        }
+      }
+    },
+    "/users/{id}/files/{wildcard}": {
+      "get": {
+        "parameters": [
+          {
+            "name": "id",
+            "in": "path",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          },
+          {
+            "name": "wildcard",
+            "in": "path",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
+        "responses": {
+          "default": {
+            "description": "Default response"
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "openAPITestUser": {
+        "type": "object",
+        "properties": {
+          "created_at": {
+            "type": "string",
+            "format": "date-time"
+          },
+          "email": {
+            "type": "string"
+          },
+          "friends": {
+            "type": "array",
+            "items": {
+              "$ref": "#/components/schemas/openAPITestUser"
+            }
+          },
+          "id": {
+            "type": "integer",
+            "format": "int64"
+          },
+          "name": {
+            "type": "string"
+          }
+        },
+        "required": [
+          "id",
+          "name",
+          "created_at"
+        ]
+      }
+    }
+  }
+}`
+	assert.Equal(t, expect, string(b))
+}
+
+func TestNewOpenAPIDocument_skipper(t *testing.T) {
+	e := New()
+	e.GET("/public", handlerFunc)
+	e.GET("/internal", handlerFunc)
+
+	doc, err := NewOpenAPIDocument(e.Router().Routes(), OpenAPIConfig{
+		Skipper: func(ri RouteInfo) bool { return ri.Path == "/internal" },
+	})
+	assert.NoError(t, err)
+	assert.Len(t, doc.Paths, 1)
+	assert.Contains(t, doc.Paths, "/public")
+}
+
+func TestNewOpenAPIDocument_hostRoutes(t *testing.T) {
+	e := New()
+	e.GET("/users", handlerFunc)
+	e.GET("/status", handlerFunc)
+	api := e.Host("api.example.com")
+	api.GET("/users", handlerFunc)
+	api.GET("/orders", handlerFunc)
+	e.Host("{tenant}.example.com").GET("/orders", handlerFunc)
+
+	config := OpenAPIConfig{
+		ServerVariables: map[string]OpenAPIServerVariable{"tenant": {Default: "acme", Description: "tenant name"}},
+	}
+	doc, err := NewOpenAPIDocument(e.Router().Routes(), config)
+	assert.NoError(t, err)
+
+	// route without host is available on any host
+	assert.Nil(t, doc.Paths["/users"]["get"].Servers)
+	assert.Nil(t, doc.Paths["/status"]["get"].Servers)
+	assert.Equal(t, []OpenAPIServer{
+		{URL: "//api.example.com"},
+		{URL: "//{tenant}.example.com", Variables: map[string]OpenAPIServerVariable{"tenant": {Default: "acme", Description: "tenant name"}}},
+	}, doc.Paths["/orders"]["get"].Servers)
+
+	rec := httptest.NewRecorder()
+	e.GET("/openapi.json", OpenAPIHandler(config))
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestNewOpenAPIDocument_duplicateOperation(t *testing.T) {
+	_, err := NewOpenAPIDocument(Routes{
+		{Method: http.MethodGet, Path: "/users", Host: "api.example.com"},
+		{Method: http.MethodGet, Path: "/users", Host: "api.example.com"},
+	}, OpenAPIConfig{})
+	assert.EqualError(t, err, "openapi: duplicate operation for GET /users")
+}
+
+func TestNewOpenAPIDocument_serverVariableWithoutDefault(t *testing.T) {
+	_, err := NewOpenAPIDocument(Routes{
+		{Method: http.MethodGet, Path: "/users", Host: "{tenant}.example.com"},
+	}, OpenAPIConfig{ServerVariables: map[string]OpenAPIServerVariable{"tenant": {Description: "tenant name"}}})
+	assert.EqualError(t, err, `openapi: GET /users: no default for server variable "tenant" of host "{tenant}.example.com", set it in OpenAPIConfig.ServerVariables`)
+}
+
+func TestNewOpenAPIDocument_hostOperationsDiffer(t *testing.T) {
+	doc, err := NewOpenAPIDocument(Routes{
+		{Method: http.MethodGet, Path: "/users", Host: "api.example.com", OpenAPI: &OpenAPIOperation{Summary: "List users"}},
+		{Method: http.MethodGet, Path: "/users", Host: "admin.example.com", OpenAPI: &OpenAPIOperation{Summary: "List users"}},
+	}, OpenAPIConfig{})
+	assert.NoError(t, err)
+	assert.Len(t, doc.Paths["/users"]["get"].Servers, 2)
+
+	_, err = NewOpenAPIDocument(Routes{
+		{Method: http.MethodGet, Path: "/users", Host: "api.example.com", OpenAPI: &OpenAPIOperation{Summary: "List users"}},
+		{Method: http.MethodGet, Path: "/users", Host: "admin.example.com", OpenAPI: &OpenAPIOperation{Summary: "List all users"}},
+	}, OpenAPIConfig{})
+	assert.EqualError(t, err, `openapi: GET /users: operation for host "admin.example.com" differs from operation for the same method and path of other host`)
+}
+
+func TestNewOpenAPIDocument_invalidRequestType(t *testing.T) {
+	_, err := NewOpenAPIDocument(Routes{
+		{Method: http.MethodPost, Path: "/", OpenAPI: &OpenAPIOperation{Request: "not a struct"}},
+	}, OpenAPIConfig{})
+	assert.EqualError(t, err, "openapi: POST /: request must be a struct")
+}
+
+func TestOpenAPIDocument_YAML(t *testing.T) {
+	doc, err := NewOpenAPIDocument(Routes{
+		{
+			Method: http.MethodGet,
+			Path:   "/items/:id",
+			OpenAPI: &OpenAPIOperation{
+				Summary:   `say "hi"`,
+				Tags:      []string{"a", "b"},
+				Responses: map[int]OpenAPIResponse{http.StatusNoContent: {}},
+			},
+		},
+	}, OpenAPIConfig{Servers: []OpenAPIServer{{URL: "https://example.com"}}})
+	assert.NoError(t, err)
+
+	b, err := doc.YAML()
+	assert.NoError(t, err)
+
+	expect := `openapi: "3.1.0"
+info:
+  title: "API"
+  version: "1.0.0"
+servers:
+  -
+    url: "https://example.com"
+paths:
+  "/items/{id}":
+    get:
+      summary: "say \"hi\""
+      tags:
+        - "a"
+        - "b"
+      parameters:
+        -
+          name: "id"
+          in: "path"
+          required: true
+          schema:
+            type: "string"
+      responses:
+        "204":
+          description: "No Content"
+`
+	assert.Equal(t, expect, string(b))
+}
+
+func TestJSONToYAML_reservedKeys(t *testing.T) {
+	b, err := jsonToYAML([]byte(`{"yes":1,"No":2,"ON":3,"off":4,"y":5,"N":6,"null":7,"True":8,"name":9}`))
+	assert.NoError(t, err)
+
+	expect := `"yes": 1
+"No": 2
+"ON": 3
+"off": 4
+"y": 5
+"N": 6
+"null": 7
+"True": 8
+name: 9
+`
+	assert.Equal(t, expect, string(b))
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	var testCases = []struct {
+		name              string
+		whenURL           string
+		expectContentType string
+	}{
+		{name: "ok, json", whenURL: "/openapi.json", expectContentType: MIMEApplicationJSON},
+		{name: "ok, yaml", whenURL: "/openapi.yaml", expectContentType: MIMEApplicationYAML},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.GET("/openapi.json", OpenAPIHandler(OpenAPIConfig{}))
+			e.GET("/openapi.yaml", OpenAPIHandler(OpenAPIConfig{}))
+			e.GET("/users", handlerFunc)
+
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectContentType, rec.Header().Get(HeaderContentType))
+			assert.Contains(t, rec.Body.String(), "/users")
+			if tc.expectContentType == MIMEApplicationJSON {
+				assert.True(t, json.Valid(rec.Body.Bytes()))
+			}
+		})
+	}
+}
+
+func TestNewOpenAPIDocument_paramConstraints(t *testing.T) {
+	e := New()
+	e.GET("/users/:id<int>/files/:name<[a-z]+>/:ref<uuid>", handlerFunc)
+
+	doc, err := NewOpenAPIDocument(e.Router().Routes(), OpenAPIConfig{})
+	assert.NoError(t, err)
+
+	op := doc.Paths["/users/{id}/files/{name}/{ref}"]["get"]
+	if assert.NotNil(t, op) {
+		assert.Equal(t, []OpenAPIParameter{
+			{Name: "id", In: "path", Required: true, Schema: &OpenAPISchema{Type: "integer"}},
+			{Name: "name", In: "path", Required: true, Schema: &OpenAPISchema{Type: "string", Pattern: "^(?:[a-z]+)$"}},
+			{Name: "ref", In: "path", Required: true, Schema: &OpenAPISchema{Type: "string", Format: "uuid"}},
+		}, op.Parameters)
+	}
+}
@@ -0,0 +1,449 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSON Patch operations as defined in RFC 6902.
+const (
+	JSONPatchOpAdd     = "add"
+	JSONPatchOpRemove  = "remove"
+	JSONPatchOpReplace = "replace"
+	JSONPatchOpMove    = "move"
+	JSONPatchOpCopy    = "copy"
+	JSONPatchOpTest    = "test"
+)
+
+// JSONPatchOperation is a single operation of RFC 6902 JSON Patch document. JSON Patch request body
+// (MIMEApplicationJSONPatchJSON) can be bound to []JSONPatchOperation.
+type JSONPatchOperation struct {
+	// Op is the operation: `add`, `remove`, `replace`, `move`, `copy` or `test`.
+	Op string `json:"op"`
+	// Path is JSON pointer (RFC 6901) to the target location of the operation.
+	Path string `json:"path"`
+	// From is JSON pointer to the source location of `move` and `copy` operations.
+	From string `json:"from,omitempty"`
+	// Value is the value of `add`, `replace` and `test` operations.
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyPatch applies patch document from the request body to target. Patch format is selected by request content type:
+// RFC 7396 JSON Merge Patch for MIMEApplicationMergePatchJSON and RFC 6902 JSON Patch for MIMEApplicationJSONPatchJSON.
+// Target must be a pointer. It is encoded to JSON, patched and the patched document is decoded to a new value that
+// replaces the value target points to, so fields that are not encoded to JSON are reset to zero values.
+//
+// Errors are HTTPError with status:
+//   - 400 when patch document is malformed.
+//   - 409 when JSON Patch `test` operation fails.
+//   - 415 when request content type is not patch content type.
+//   - 422 when patch can not be applied (i.e. path does not exist) or patched document does not fit target type.
+//
+// Example:
+//
+//	user, err := store.User(c.Param("id"))
+//	if err != nil {
+//		return err
+//	}
+//	if err := echo.ApplyPatch(c, &user); err != nil {
+//		return err
+//	}
+func ApplyPatch(c *Context, target any) error {
+	base, _, _ := strings.Cut(c.Request().Header.Get(HeaderContentType), ";")
+	mediatype := strings.ToLower(strings.TrimSpace(base))
+	if mediatype != MIMEApplicationMergePatchJSON && mediatype != MIMEApplicationJSONPatchJSON {
+		return ErrUnsupportedMediaType.Wrap(fmt.Errorf("unsupported patch content type %q", mediatype))
+	}
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("patch target must be a non-nil pointer, got %T", target)
+	}
+
+	patch, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return ErrBadRequest.Wrap(err)
+	}
+	document, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+	if mediatype == MIMEApplicationMergePatchJSON {
+		document, err = ApplyMergePatch(document, patch)
+	} else {
+		document, err = ApplyJSONPatch(document, patch)
+	}
+	if err != nil {
+		return err
+	}
+
+	patched := reflect.New(rv.Elem().Type())
+	if err := json.Unmarshal(document, patched.Interface()); err != nil {
+		return patchError(http.StatusUnprocessableEntity, "patched document does not fit target", err)
+	}
+	rv.Elem().Set(patched.Elem())
+	return nil
+}
+
+// ApplyMergePatch applies RFC 7396 JSON Merge Patch to JSON document and returns the patched document.
+func ApplyMergePatch(document []byte, patch []byte) ([]byte, error) {
+	doc, err := decodePatchJSON(document)
+	if err != nil {
+		return nil, patchError(http.StatusUnprocessableEntity, "invalid JSON document", err)
+	}
+	p, err := decodePatchJSON(patch)
+	if err != nil {
+		return nil, patchError(http.StatusBadRequest, "invalid merge patch document", err)
+	}
+	return json.Marshal(mergePatch(doc, p))
+}
+
+func mergePatch(target any, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+		} else {
+			targetObj[key] = mergePatch(targetObj[key], value)
+		}
+	}
+	return targetObj
+}
+
+// ApplyJSONPatch applies RFC 6902 JSON Patch to JSON document and returns the patched document. Operations are applied
+// in order and the document is not changed when any of the operations fails.
+func ApplyJSONPatch(document []byte, patch []byte) ([]byte, error) {
+	doc, err := decodePatchJSON(document)
+	if err != nil {
+		return nil, patchError(http.StatusUnprocessableEntity, "invalid JSON document", err)
+	}
+	var ops []JSONPatchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, patchError(http.StatusBadRequest, "invalid JSON patch document", err)
+	}
+	for i, op := range ops {
+		if doc, err = applyJSONPatchOperation(doc, op); err != nil {
+			var hErr *HTTPError
+			if errors.As(err, &hErr) {
+				hErr.Message = fmt.Sprintf("JSON patch operation %d: %s", i, hErr.Message)
+			}
+			return nil, err
+		}
+	}
+	return json.Marshal(doc)
+}
+
+func applyJSONPatchOperation(doc any, op JSONPatchOperation) (any, error) {
+	path, err := parseJSONPointer(op.Path)
+	if err != nil {
+		return nil, patchError(http.StatusBadRequest, "invalid path", err)
+	}
+	var value any
+	switch op.Op {
+	case JSONPatchOpAdd, JSONPatchOpReplace, JSONPatchOpTest:
+		if len(op.Value) == 0 {
+			return nil, patchError(http.StatusBadRequest, op.Op+" operation is missing value", nil)
+		}
+		if value, err = decodePatchJSON(op.Value); err != nil {
+			return nil, patchError(http.StatusBadRequest, "invalid value", err)
+		}
+	}
+
+	switch op.Op {
+	case JSONPatchOpAdd:
+		return jsonPatchAdd(doc, path, value)
+	case JSONPatchOpRemove:
+		doc, _, err = jsonPatchRemove(doc, path)
+		return doc, err
+	case JSONPatchOpReplace:
+		if doc, _, err = jsonPatchRemove(doc, path); err != nil {
+			return nil, err
+		}
+		return jsonPatchAdd(doc, path, value)
+	case JSONPatchOpMove, JSONPatchOpCopy:
+		from, err := parseJSONPointer(op.From)
+		if err != nil {
+			return nil, patchError(http.StatusBadRequest, "invalid from", err)
+		}
+		if op.Op == JSONPatchOpMove {
+			if len(from) < len(path) && isJSONPointerPrefix(from, path) {
+				return nil, patchError(http.StatusUnprocessableEntity, "path "+op.Path+" is inside from "+op.From, nil)
+			}
+			if doc, value, err = jsonPatchRemove(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = jsonPatchGet(doc, from); err != nil {
+				return nil, err
+			}
+			value = copyPatchValue(value)
+		}
+		return jsonPatchAdd(doc, path, value)
+	case JSONPatchOpTest:
+		actual, err := jsonPatchGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equalPatchValues(actual, value) {
+			return nil, patchError(http.StatusConflict, "test failed for path "+op.Path, nil)
+		}
+		return doc, nil
+	default:
+		return nil, patchError(http.StatusBadRequest, fmt.Sprintf("unknown operation %q", op.Op), nil)
+	}
+}
+
+func jsonPatchGet(doc any, path []string) (any, error) {
+	for i, token := range path {
+		switch container := doc.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, pathNotFoundError(path[:i+1])
+			}
+			doc = value
+		case []any:
+			index, err := jsonPatchIndex(token, len(container)-1)
+			if err != nil {
+				return nil, pathError(path[:i+1], err)
+			}
+			doc = container[index]
+		default:
+			return nil, pathNotFoundError(path[:i+1])
+		}
+	}
+	return doc, nil
+}
+
+// jsonPatchUpdate replaces container that holds location of path with the container returned by fn and returns the
+// updated document.
+func jsonPatchUpdate(doc any, path []string, depth int, fn func(container any, token string) (any, error)) (any, error) {
+	token := path[depth]
+	if depth == len(path)-1 {
+		return fn(doc, token)
+	}
+	switch container := doc.(type) {
+	case map[string]any:
+		child, ok := container[token]
+		if !ok {
+			return nil, pathNotFoundError(path[:depth+1])
+		}
+		updated, err := jsonPatchUpdate(child, path, depth+1, fn)
+		if err != nil {
+			return nil, err
+		}
+		container[token] = updated
+		return container, nil
+	case []any:
+		index, err := jsonPatchIndex(token, len(container)-1)
+		if err != nil {
+			return nil, pathError(path[:depth+1], err)
+		}
+		updated, err := jsonPatchUpdate(container[index], path, depth+1, fn)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = updated
+		return container, nil
+	default:
+		return nil, pathNotFoundError(path[:depth+1])
+	}
+}
+
+func jsonPatchAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return jsonPatchUpdate(doc, path, 0, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			c[token] = value
+			return c, nil
+		case []any:
+			if token == "-" {
+				return append(c, value), nil
+			}
+			index, err := jsonPatchIndex(token, len(c))
+			if err != nil {
+				return nil, pathError(path, err)
+			}
+			c = append(c, nil)
+			copy(c[index+1:], c[index:])
+			c[index] = value
+			return c, nil
+		default:
+			return nil, pathNotFoundError(path)
+		}
+	})
+}
+
+// jsonPatchRemove removes value at path and returns the updated document and the removed value.
+func jsonPatchRemove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	var removed any
+	doc, err := jsonPatchUpdate(doc, path, 0, func(container any, token string) (any, error) {
+		switch c := container.(type) {
+		case map[string]any:
+			value, ok := c[token]
+			if !ok {
+				return nil, pathNotFoundError(path)
+			}
+			removed = value
+			delete(c, token)
+			return c, nil
+		case []any:
+			index, err := jsonPatchIndex(token, len(c)-1)
+			if err != nil {
+				return nil, pathError(path, err)
+			}
+			removed = c[index]
+			return append(c[:index:index], c[index+1:]...), nil
+		default:
+			return nil, pathNotFoundError(path)
+		}
+	})
+	return doc, removed, err
+}
+
+// jsonPatchIndex parses array index token. Index must be in range [0, maxIndex].
+func jsonPatchIndex(token string, maxIndex int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || !isDigits(token) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index > maxIndex {
+		return 0, fmt.Errorf("array index %s out of bounds", token)
+	}
+	return index, nil
+}
+
+// parseJSONPointer parses JSON pointer (RFC 6901) to unescaped reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("JSON pointer %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		if strings.Contains(strings.ReplaceAll(strings.ReplaceAll(token, "~0", ""), "~1", ""), "~") {
+			return nil, fmt.Errorf("JSON pointer %q has invalid escape sequence", pointer)
+		}
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isJSONPointerPrefix(prefix []string, path []string) bool {
+	for i, token := range prefix {
+		if path[i] != token {
+			return false
+		}
+	}
+	return true
+}
+
+func decodePatchJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+	return v, nil
+}
+
+func copyPatchValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(t))
+		for k, value := range t {
+			result[k] = copyPatchValue(value)
+		}
+		return result
+	case []any:
+		result := make([]any, len(t))
+		for i, value := range t {
+			result[i] = copyPatchValue(value)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// equalPatchValues compares JSON values. Numbers are compared by their numeric value.
+func equalPatchValues(a any, b any) bool {
+	switch at := a.(type) {
+	case json.Number:
+		bt, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		if at == bt {
+			return true
+		}
+		af, errA := at.Float64()
+		bf, errB := bt.Float64()
+		return errA == nil && errB == nil && af == bf
+	case map[string]any:
+		bt, ok := b.(map[string]any)
+		if !ok || len(at) != len(bt) {
+			return false
+		}
+		for k, v := range at {
+			bv, ok := bt[k]
+			if !ok || !equalPatchValues(v, bv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bt, ok := b.([]any)
+		if !ok || len(at) != len(bt) {
+			return false
+		}
+		for i := range at {
+			if !equalPatchValues(at[i], bt[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func patchError(code int, message string, err error) error {
+	return &HTTPError{Code: code, Message: message, err: err}
+}
+
+func pathNotFoundError(path []string) error {
+	return patchError(http.StatusUnprocessableEntity, "path "+jsonPointer(path)+" does not exist", nil)
+}
+
+func pathError(path []string, err error) error {
+	return patchError(http.StatusUnprocessableEntity, "invalid path "+jsonPointer(path), err)
+}
@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenDoc     string
+		givenPatch   string
+		expect       string
+		expectStatus int
+		expectError  string
+	}{
+		{
+			name:       "ok, add, replace and remove",
+			givenDoc:   `{"name":"Jon","tags":["a","c"],"age":30}`,
+			givenPatch: `[{"op":"add","path":"/tags/1","value":"b"},{"op":"add","path":"/tags/-","value":"d"},{"op":"replace","path":"/name","value":"Doe"},{"op":"remove","path":"/age"}]`,
+			expect:     `{"name":"Doe","tags":["a","b","c","d"]}`,
+		},
+		{
+			name:       "ok, move and copy",
+			givenDoc:   `{"a":{"b":1},"list":[1,2]}`,
+			givenPatch: `[{"op":"copy","from":"/a","path":"/c"},{"op":"move","from":"/a/b","path":"/list/0"},{"op":"add","path":"/c/x","value":true}]`,
+			expect:     `{"a":{},"c":{"b":1,"x":true},"list":[1,1,2]}`,
+		},
+		{
+			name:       "ok, escaped pointer and successful test",
+			givenDoc:   `{"a/b":{"m~n":1.0}}`,
+			givenPatch: `[{"op":"test","path":"/a~1b/m~0n","value":1},{"op":"replace","path":"","value":[null]}]`,
+			expect:     `[null]`,
+		},
+		{
+			name:         "nok, test failed",
+			givenDoc:     `{"version":2}`,
+			givenPatch:   `[{"op":"test","path":"/version","value":1},{"op":"replace","path":"/version","value":3}]`,
+			expectStatus: http.StatusConflict,
+			expectError:  "code=409, message=JSON patch operation 0: test failed for path /version",
+		},
+		{
+			name:         "nok, path does not exist",
+			givenDoc:     `{"a":{}}`,
+			givenPatch:   `[{"op":"remove","path":"/a/b"}]`,
+			expectStatus: http.StatusUnprocessableEntity,
+			expectError:  "code=422, message=JSON patch operation 0: path /a/b does not exist",
+		},
+		{
+			name:         "nok, array index out of bounds",
+			givenDoc:     `{"list":[1]}`,
+			givenPatch:   `[{"op":"add","path":"/list/2","value":1}]`,
+			expectStatus: http.StatusUnprocessableEntity,
+			expectError:  "code=422, message=JSON patch operation 0: invalid path /list/2, err=array index 2 out of bounds",
+		},
+		{
+			name:         "nok, array index with leading zero",
+			givenDoc:     `{"list":[1,2]}`,
+			givenPatch:   `[{"op":"replace","path":"/list/01","value":1}]`,
+			expectStatus: http.StatusUnprocessableEntity,
+			expectError:  `code=422, message=JSON patch operation 0: invalid path /list/01, err=invalid array index "01"`,
+		},
+		{
+			name:         "nok, move to own child",
+			givenDoc:     `{"a":{"b":{}}}`,
+			givenPatch:   `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			expectStatus: http.StatusUnprocessableEntity,
+			expectError:  "code=422, message=JSON patch operation 0: path /a/b/c is inside from /a",
+		},
+		{
+			name:         "nok, invalid pointer",
+			givenDoc:     `{}`,
+			givenPatch:   `[{"op":"add","path":"a","value":1}]`,
+			expectStatus: http.StatusBadRequest,
+			expectError:  `code=400, message=JSON patch operation 0: invalid path, err=JSON pointer "a" must start with /`,
+		},
+		{
+			name:         "nok, missing value",
+			givenDoc:     `{}`,
+			givenPatch:   `[{"op":"add","path":"/a"}]`,
+			expectStatus: http.StatusBadRequest,
+			expectError:  "code=400, message=JSON patch operation 0: add operation is missing value",
+		},
+		{
+			name:         "nok, unknown operation",
+			givenDoc:     `{}`,
+			givenPatch:   `[{"op":"merge","path":"/a"}]`,
+			expectStatus: http.StatusBadRequest,
+			expectError:  `code=400, message=JSON patch operation 0: unknown operation "merge"`,
+		},
+		{
+			name:         "nok, patch is not an array",
+			givenDoc:     `{}`,
+			givenPatch:   `{"op":"add","path":"/a","value":1}`,
+			expectStatus: http.StatusBadRequest,
+			expectError:  "code=400, message=invalid JSON patch document, err=json: cannot unmarshal object into Go value of type []echo.JSONPatchOperation",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ApplyJSONPatch([]byte(tc.givenDoc), []byte(tc.givenPatch))
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				assert.Equal(t, tc.expectStatus, StatusCode(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expect, string(result))
+		})
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenDoc    string
+		givenPatch  string
+		expect      string
+		expectError string
+	}{
+		{
+			name:       "ok, merge nested objects",
+			givenDoc:   `{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"text"}`,
+			givenPatch: `{"title":"Hello!","phoneNumber":"+01-123-456-7890","author":{"familyName":null},"tags":["example"]}`,
+			expect:     `{"title":"Hello!","author":{"givenName":"John"},"tags":["example"],"content":"text","phoneNumber":"+01-123-456-7890"}`,
+		},
+		{
+			name:       "ok, non-object patch replaces document",
+			givenDoc:   `{"a":1}`,
+			givenPatch: `["b"]`,
+			expect:     `["b"]`,
+		},
+		{
+			name:       "ok, object patch replaces non-object member",
+			givenDoc:   `{"a":"x","n":12345678901234567890}`,
+			givenPatch: `{"a":{"b":null,"c":1}}`,
+			expect:     `{"a":{"c":1},"n":12345678901234567890}`,
+		},
+		{
+			name:        "nok, invalid patch",
+			givenDoc:    `{}`,
+			givenPatch:  `{"a":`,
+			expectError: "code=400, message=invalid merge patch document, err=unexpected EOF",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ApplyMergePatch([]byte(tc.givenDoc), []byte(tc.givenPatch))
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expect, string(result))
+		})
+	}
+}
+
+type patchTestUser struct {
+	Name   string   `json:"name"`
+	Email  string   `json:"email,omitempty"`
+	Age    int      `json:"age"`
+	Groups []string `json:"groups"`
+}
+
+func TestApplyPatch(t *testing.T) {
+	var testCases = []struct {
+		name         string
+		givenType    string
+		givenBody    string
+		expect       patchTestUser
+		expectStatus int
+		expectError  string
+	}{
+		{
+			name:      "ok, merge patch",
+			givenType: MIMEApplicationMergePatchJSON,
+			givenBody: `{"email":"jon@example.com","groups":null}`,
+			expect:    patchTestUser{Name: "Jon", Email: "jon@example.com", Age: 30},
+		},
+		{
+			name:      "ok, JSON patch with charset",
+			givenType: MIMEApplicationJSONPatchJSON + "; charset=utf-8",
+			givenBody: `[{"op":"test","path":"/age","value":30},{"op":"replace","path":"/age","value":31},{"op":"add","path":"/groups/-","value":"dev"}]`,
+			expect:    patchTestUser{Name: "Jon", Age: 31, Groups: []string{"admin", "dev"}},
+		},
+		{
+			name:         "nok, patched document does not fit target",
+			givenType:    MIMEApplicationMergePatchJSON,
+			givenBody:    `{"age":"thirty"}`,
+			expectStatus: http.StatusUnprocessableEntity,
+			expectError:  "code=422, message=patched document does not fit target, err=json: cannot unmarshal string into Go struct field patchTestUser.age of type int",
+		},
+		{
+			name:         "nok, not a patch content type",
+			givenType:    MIMEApplicationJSON,
+			givenBody:    `{"age":31}`,
+			expectStatus: http.StatusUnsupportedMediaType,
+			expectError:  `code=415, message=Unsupported Media Type, err=unsupported patch content type "application/json"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tc.givenBody))
+			req.Header.Set(HeaderContentType, tc.givenType)
+			c := New().NewContext(req, httptest.NewRecorder())
+
+			user := patchTestUser{Name: "Jon", Age: 30, Groups: []string{"admin"}}
+			err := ApplyPatch(c, &user)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				assert.Equal(t, tc.expectStatus, StatusCode(err))
+				assert.Equal(t, patchTestUser{Name: "Jon", Age: 30, Groups: []string{"admin"}}, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, user)
+		})
+	}
+}
+
+func TestDefaultBinder_Bind_patchContentTypes(t *testing.T) {
+	e := New()
+
+	req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`{"name":"Doe"}`))
+	req.Header.Set(HeaderContentType, MIMEApplicationMergePatchJSON)
+	c := e.NewContext(req, httptest.NewRecorder())
+	user := patchTestUser{Name: "Jon", Age: 30}
+	assert.NoError(t, c.Bind(&user))
+	assert.Equal(t, patchTestUser{Name: "Doe", Age: 30}, user)
+
+	req = httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(`[{"op":"remove","path":"/age"}]`))
+	req.Header.Set(HeaderContentType, MIMEApplicationJSONPatchJSON)
+	c = e.NewContext(req, httptest.NewRecorder())
+	var ops []JSONPatchOperation
+	assert.NoError(t, c.Bind(&ops))
+	assert.Equal(t, []JSONPatchOperation{{Op: JSONPatchOpRemove, Path: "/age"}}, ops)
+}
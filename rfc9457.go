@@ -24,6 +24,9 @@ type ProblemError struct {
 	Detail string `json:"detail,omitempty"`
 	// Instance is a URI reference that identifies the specific occurrence of the problem.
 	Instance string `json:"instance,omitempty"`
+	// Errors is an extension member that lists individual errors of the problem, i.e. fields of the request that
+	// failed to bind (see BindingErrors). Omitted when empty.
+	Errors any `json:"errors,omitempty"`
 }
 
 // Error makes ProblemError compatible with the `error` interface.
@@ -130,6 +130,18 @@ func TestProblemDetailsHTTPErrorHandler(t *testing.T) {
 			expectStatus: http.StatusInternalServerError,
 			expectBody:   `{"type":"about:blank","title":"Internal Server Error","status":500}` + "\n",
 		},
+		{
+			name:       "ok, BindingErrors lists fields in errors member",
+			whenMethod: http.MethodGet,
+			whenError: NewBindingErrors([]*BindingError{
+				{Source: "query", Field: "page", HTTPError: &HTTPError{Code: http.StatusBadRequest, Message: "failed to bind field value"}},
+				{Source: "form", Field: "items[0][qty]", HTTPError: &HTTPError{Code: http.StatusBadRequest, Message: "failed to bind field value"}},
+			}),
+			expectStatus: http.StatusBadRequest,
+			expectBody: `{"type":"about:blank","title":"Bad Request","status":400,"errors":[` +
+				`{"source":"query","field":"page","message":"failed to bind field value"},` +
+				`{"source":"form","field":"items[0][qty]","message":"failed to bind field value"}]}` + "\n",
+		},
 	}
 
 	for _, tc := range testCases {
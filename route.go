@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"runtime"
 )
@@ -17,15 +18,29 @@ type Route struct {
 	Method string
 	Path   string
 	Name   string
+	// Host is the host pattern (`example.com`, `{tenant}.example.com`) request Host must match for the route to be
+	// matched. Placeholders in curly braces match single part of the host and are added to the Context path values.
+	// Empty Host matches any host. Port is ignored when matching.
+	Host string
 
 	// HandlerFunc is a function that handles HTTP requests. This could be left nil when the Router implementation allows
 	// fallback to default/global handlers in certain situations.
 	Handler     HandlerFunc
 	Middlewares []MiddlewareFunc
 
+	// OpenAPI describes the route for OpenAPI document generation. See NewOpenAPIDocument.
+	OpenAPI *OpenAPIOperation
+	// Metadata is arbitrary user data attached to the route (required permissions, rate-limit tier, deprecation date
+	// etc.). It is copied to RouteInfo and is accessible during request handling with `c.RouteInfo().Metadata`.
+	Metadata map[string]any
+
 	// allowOverwrite permits this route to replace an existing route with the same method+path,
 	// overriding the router's AllowOverwritingRoute config for this specific registration.
 	allowOverwrite bool
+	// mount is set for routes registered by Echo.Mount to route requests to the mounted Echo instance.
+	mount *mountPoint
+	// group is the Group route was added with. Context resolves group components for the matched route from it.
+	group *Group
 }
 
 // ToRouteInfo converts Route to RouteInfo
@@ -40,6 +55,13 @@ func (r Route) ToRouteInfo(params []string) RouteInfo {
 		Path:       r.Path,
 		Parameters: append([]string(nil), params...),
 		Name:       name,
+		Host:       r.Host,
+		OpenAPI:    r.OpenAPI,
+		Metadata:   maps.Clone(r.Metadata),
+
+		ParamConstraints: pathParamConstraints(r.Path),
+		mount:            r.mount,
+		group:            r.group,
 	}
 }
 
@@ -62,6 +84,22 @@ type RouteInfo struct {
 	Method     string
 	Path       string
 	Parameters []string
+	// Host is the host pattern of the route. Empty for routes that match any host.
+	Host string
+	// ParamConstraints contains path parameter constraints (`:id<int>` results `{"id": "int"}`) by parameter name.
+	// Nil when route path has no constrained parameters.
+	ParamConstraints map[string]string
+
+	// OpenAPI describes the route for OpenAPI document generation. See NewOpenAPIDocument.
+	OpenAPI *OpenAPIOperation
+	// Metadata is arbitrary user data attached to the route with Route.Metadata.
+	Metadata map[string]any
+
+	// mount is set for routes registered by Echo.Mount. Routers replace these routes with routes of the mounted Echo
+	// instance when listing routes.
+	mount *mountPoint
+	// group is the Group route was added with.
+	group *Group
 
 	// NOTE: handler and middlewares are not exposed because handler could be already wrapping middlewares. Therefore,
 	// it is not always 100% known if handler function already wraps middlewares or not. In Echo handler could be one
@@ -75,11 +113,30 @@ func (r RouteInfo) Clone() RouteInfo {
 		Method:     r.Method,
 		Path:       r.Path,
 		Parameters: append([]string(nil), r.Parameters...),
+		Host:       r.Host,
+		OpenAPI:    r.OpenAPI,
+		Metadata:   maps.Clone(r.Metadata),
+
+		ParamConstraints: maps.Clone(r.ParamConstraints),
+		mount:            r.mount,
+		group:            r.group,
 	}
 }
 
-// Reverse reverses route to URL string by replacing path parameters with given params values.
+// Reverse reverses route to URL string by replacing path parameters with given params values. Returns empty string
+// when value does not satisfy path parameter constraint, use Routes.Reverse to get the error.
 func (r RouteInfo) Reverse(pathValues ...any) string {
+	uri, err := r.reverse(pathValues...)
+	if err != nil {
+		return ""
+	}
+	return uri
+}
+
+func (r RouteInfo) reverse(pathValues ...any) (string, error) {
+	if err := r.checkParamConstraints(pathValues); err != nil {
+		return "", err
+	}
 	uri := new(bytes.Buffer)
 	ln := len(pathValues)
 	n := 0
@@ -90,8 +147,7 @@ func (r RouteInfo) Reverse(pathValues ...any) string {
 		}
 		if n < ln && (r.Path[i] == anyLabel || (!hasBackslash && r.Path[i] == paramLabel)) {
 			// in case of `*` wildcard or `:` (unescaped colon) param we replace everything till next slash or end of path
-			for ; i < l && r.Path[i] != '/'; i++ {
-			}
+			i = paramSegmentEnd(r.Path, i)
 			fmt.Fprintf(uri, "%v", pathValues[n])
 			n++
 		}
@@ -99,7 +155,28 @@ func (r RouteInfo) Reverse(pathValues ...any) string {
 			uri.WriteByte(r.Path[i])
 		}
 	}
-	return uri.String()
+	return uri.String(), nil
+}
+
+// checkParamConstraints checks that given path parameter values satisfy path parameter constraints of the route.
+func (r RouteInfo) checkParamConstraints(pathValues []any) error {
+	for i, name := range r.Parameters {
+		if i >= len(pathValues) {
+			break
+		}
+		expr, ok := r.ParamConstraints[name]
+		if !ok {
+			continue
+		}
+		pc, err := compileParamConstraint(expr)
+		if err != nil {
+			return err
+		}
+		if v := fmt.Sprintf("%v", pathValues[i]); !pc.match(v) {
+			return fmt.Errorf("path parameter %q value %q does not satisfy constraint %q", name, v, expr)
+		}
+	}
+	return nil
 }
 
 // HandlerName returns string name for given function.
@@ -120,11 +197,12 @@ func (r Routes) Clone() Routes {
 	return result
 }
 
-// Reverse reverses route to URL string by replacing path parameters with given params values.
+// Reverse reverses route to URL string by replacing path parameters with given params values. Returns an error when
+// value does not satisfy path parameter constraint.
 func (r Routes) Reverse(routeName string, pathValues ...any) (string, error) {
 	for _, rr := range r {
 		if rr.Name == routeName {
-			return rr.Reverse(pathValues...), nil
+			return rr.reverse(pathValues...)
 		}
 	}
 	return "", errors.New("route not found")
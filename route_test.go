@@ -123,6 +123,21 @@ func TestRoute_ToRouteInfo(t *testing.T) {
 				Name:       "GET:users/:id/:file",
 			},
 		},
+		{
+			name: "ok, metadata",
+			given: Route{
+				Method:   http.MethodGet,
+				Path:     "/test",
+				Handler:  handlerFunc,
+				Metadata: map[string]any{"permission": "users:read"},
+			},
+			expect: RouteInfo{
+				Method:   http.MethodGet,
+				Path:     "/test",
+				Name:     "GET:/test",
+				Metadata: map[string]any{"permission": "users:read"},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -158,6 +173,33 @@ func TestRoute_ForGroup(t *testing.T) {
 	assert.Equal(t, r.Name, "test route")
 }
 
+func TestRoute_WithPrefixKeepsMetadata(t *testing.T) {
+	route := Route{
+		Method:   http.MethodGet,
+		Path:     "/test",
+		Handler:  handlerFunc,
+		Metadata: map[string]any{"tier": "gold"},
+	}
+
+	r := route.WithPrefix("/users", nil)
+
+	assert.Equal(t, map[string]any{"tier": "gold"}, r.Metadata)
+}
+
+func TestRouteInfo_CloneMetadata(t *testing.T) {
+	ri := RouteInfo{
+		Method:   http.MethodGet,
+		Path:     "/test",
+		Metadata: map[string]any{"tier": "gold"},
+	}
+
+	cloned := ri.Clone()
+	assert.Equal(t, ri, cloned)
+
+	cloned.Metadata["tier"] = "silver"
+	assert.Equal(t, "gold", ri.Metadata["tier"])
+}
+
 func exampleRoutes() Routes {
 	return Routes{
 		RouteInfo{
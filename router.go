@@ -6,6 +6,8 @@ package echo
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 )
@@ -57,6 +59,7 @@ type Routes []RouteInfo
 // DefaultRouter is the registry of all registered routes for an `Echo` instance for
 // request matching and URL path parameter parsing.
 // Note: DefaultRouter is not coroutine-safe. Do not Add/Remove routes after HTTP server has been started with Echo.
+// Use NewConcurrentRouter or NewCopyOnWriteRouter when routes need to be changed at runtime.
 type DefaultRouter struct {
 	tree                    *node
 	notFoundHandler         HandlerFunc
@@ -66,6 +69,13 @@ type DefaultRouter struct {
 	// maxPathParamsLength tracks highest count of PathValues for all routes.
 	maxPathParamsLength int
 
+	// hosts contains routers for routes with Route.Host set. Nil when there are no host routes.
+	hosts *hostRouters
+	// host is the host pattern this router serves. Nil for the main router.
+	host *hostPattern
+	// hasMounts is true when routes registered with Echo.Mount have been added.
+	hasMounts bool
+
 	allowOverwritingRoute    bool
 	unescapePathParamValues  bool
 	useEscapedPathForRouting bool
@@ -167,7 +177,13 @@ type node struct {
 	// scLabels holds the first byte (label) of each staticChildren entry in the
 	// same order. Scanning this contiguous byte slice during routing is more
 	// cache-friendly than dereferencing each *node to read its label.
-	scLabels    []byte
+	scLabels []byte
+	// paramConstraint is constraint path parameter value must satisfy to match param node. Nil for unconstrained
+	// param nodes.
+	paramConstraint *paramConstraint
+	// nextParam is the next param node of the same parent with different constraint. Param nodes are chained in the
+	// order they were added, starting from parent paramChild, and are tried in that order during routing.
+	nextParam   *node
 	paramsCount int
 	kind        kind
 	label       byte
@@ -188,6 +204,8 @@ const (
 
 type routeMethod struct {
 	*RouteInfo
+	// paramsCount is count of path parameters. RouteInfo.Parameters of host routes contain also host parameters.
+	paramsCount        int
 	handler            HandlerFunc
 	wrappedHeadHandler HandlerFunc // non-nil only for GET routes when autoHandleHEAD=true
 	orgRouteInfo       RouteInfo
@@ -215,6 +233,9 @@ type routeMethods struct {
 	// allowHeader contains comma-separated list of Methods registered to this node path.
 	// it is optimization for http.StatusMethodNotAllowed (405) handling.
 	allowHeader string
+	// group is the Group of the last route registered to this node path. It is used for http.StatusMethodNotAllowed
+	// (405) handling.
+	group *Group
 }
 
 func (m *routeMethods) set(method string, r *routeMethod) {
@@ -258,6 +279,9 @@ func (m *routeMethods) set(method string, r *routeMethod) {
 			m.anyOther[method] = r
 		}
 	}
+	if r != nil && r.RouteInfo != nil && r.group != nil {
+		m.group = r.group
+	}
 	m.updateAllowHeader()
 }
 
@@ -375,13 +399,36 @@ func (m *routeMethods) isHandler() bool {
 	// RouteNotFound/404 is not considered as a handler
 }
 
-// Routes returns all registered routes
+// Routes returns all registered routes. Host routes are listed after routes without host.
 func (r *DefaultRouter) Routes() Routes {
-	return r.routes
+	if r.hosts == nil && !r.hasMounts {
+		return r.routes
+	}
+	result := appendRoutes(make(Routes, 0, len(r.routes)), r.routes)
+	if r.hosts != nil {
+		for _, hr := range r.hosts.all {
+			result = appendRoutes(result, hr.routes)
+		}
+	}
+	return result
 }
 
-// Remove unregisters registered route
+// Remove unregisters registered route. Routes without host are searched first and then host routes in order
+// their hosts were added.
 func (r *DefaultRouter) Remove(method string, path string) error {
+	err := r.remove(method, path)
+	if err == nil || r.hosts == nil {
+		return err
+	}
+	for _, hr := range r.hosts.all {
+		if hr.remove(method, path) == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (r *DefaultRouter) remove(method string, path string) error {
 	currentNode := r.tree
 	if currentNode == nil || (currentNode.isLeaf && !currentNode.isHandler) {
 		return errors.New("router has no routes to remove")
@@ -414,7 +461,12 @@ func (r *DefaultRouter) Remove(method string, path string) error {
 		next := path[prefixLen]
 		switch next {
 		case paramLabel:
-			currentNode = currentNode.paramChild
+			_, expr := splitParamConstraint(path[prefixLen+1 : paramSegmentEnd(path, prefixLen)])
+			var constraint *paramConstraint
+			if expr != "" {
+				constraint, _ = compileParamConstraint(expr)
+			}
+			currentNode = currentNode.findParamChild(constraint)
 		case anyLabel:
 			currentNode = currentNode.anyChild
 		default:
@@ -468,7 +520,7 @@ func (r *DefaultRouter) Remove(method string, path string) error {
 				parent.staticChildren = append(parent.staticChildren[:index], parent.staticChildren[index+1:]...)
 				parent.scLabels = append(parent.scLabels[:index], parent.scLabels[index+1:]...)
 			case paramKind:
-				parent.paramChild = nil
+				parent.removeParamChild(current)
 			case anyKind:
 				parent.anyChild = nil
 			}
@@ -506,7 +558,18 @@ func newAddRouteError(route Route, err error) *AddRouteError {
 
 // Add registers a new route for method and path with matching handler.
 func (r *DefaultRouter) Add(route Route) (RouteInfo, error) {
+	if route.Host != "" && r.host == nil {
+		hr, err := r.hostRouter(route.Host)
+		if err != nil {
+			return RouteInfo{}, newAddRouteError(route, err)
+		}
+		return hr.Add(route)
+	}
+
 	allowOverwritingRoute := r.allowOverwritingRoute || route.allowOverwrite
+	if route.mount != nil {
+		r.hasMounts = true
+	}
 
 	if route.Handler == nil {
 		switch route.Method {
@@ -522,6 +585,18 @@ func (r *DefaultRouter) Add(route Route) (RouteInfo, error) {
 	method := route.Method
 	path := normalizePathSlash(route.Path)
 
+	if err := checkParamConstraintSyntax(path); err != nil {
+		return RouteInfo{}, newAddRouteError(route, err)
+	}
+	constraints := make(map[string]*paramConstraint)
+	for name, expr := range pathParamConstraints(path) {
+		pc, err := compileParamConstraint(expr)
+		if err != nil {
+			return RouteInfo{}, newAddRouteError(route, fmt.Errorf("invalid constraint for path parameter %q: %w", name, err))
+		}
+		constraints[name] = pc
+	}
+
 	h := applyMiddleware(route.Handler, route.Middlewares...)
 	if !allowOverwritingRoute {
 		for _, rr := range r.routes {
@@ -536,6 +611,7 @@ func (r *DefaultRouter) Add(route Route) (RouteInfo, error) {
 	}
 
 	paramNames := make([]string, 0)
+	paramConstraints := make([]*paramConstraint, 0)
 	originalPath := path
 	wasAdded := false
 	var ri RouteInfo
@@ -549,54 +625,57 @@ func (r *DefaultRouter) Add(route Route) (RouteInfo, error) {
 			}
 			j := i + 1
 
-			r.insert(staticKind, path[:i], method, routeMethod{RouteInfo: &RouteInfo{Method: method}})
-			for ; i < lcpIndex && path[i] != '/'; i++ {
-			}
+			r.insert(staticKind, path[:i], method, routeMethod{RouteInfo: &RouteInfo{Method: method}}, paramConstraints)
+			i = paramSegmentEnd(path, i)
 
-			paramNames = append(paramNames, path[j:i])
+			name, _ := splitParamConstraint(path[j:i])
+			paramNames = append(paramNames, name)
+			paramConstraints = append(paramConstraints, constraints[name])
 			path = path[:j] + path[i:]
 			i, lcpIndex = j, len(path)
 
 			if i == lcpIndex {
 				// path node is last fragment of route path. ie. `/users/:id`
-				ri = route.ToRouteInfo(paramNames)
+				ri = route.ToRouteInfo(r.withHostParams(paramNames))
 				rm := routeMethod{
-					RouteInfo:          &RouteInfo{Method: method, Path: originalPath, Parameters: paramNames, Name: route.Name},
+					RouteInfo:          matchedRouteInfo(route, originalPath, r.withHostParams(paramNames)),
+					paramsCount:        len(paramNames),
 					handler:            h,
 					orgRouteInfo:       ri,
 					wrappedHeadHandler: headH,
 				}
-				r.insert(paramKind, path[:i], method, rm)
+				r.insert(paramKind, path[:i], method, rm, paramConstraints)
 				wasAdded = true
 				break
-			} else {
-				r.insert(paramKind, path[:i], method, routeMethod{RouteInfo: &RouteInfo{Method: method}})
 			}
+			r.insert(paramKind, path[:i], method, routeMethod{RouteInfo: &RouteInfo{Method: method}}, paramConstraints)
 		} else if path[i] == anyLabel {
-			r.insert(staticKind, path[:i], method, routeMethod{RouteInfo: &RouteInfo{Method: method}})
+			r.insert(staticKind, path[:i], method, routeMethod{RouteInfo: &RouteInfo{Method: method}}, paramConstraints)
 			paramNames = append(paramNames, "*")
-			ri = route.ToRouteInfo(paramNames)
+			ri = route.ToRouteInfo(r.withHostParams(paramNames))
 			rm := routeMethod{
-				RouteInfo:          &RouteInfo{Method: method, Path: originalPath, Parameters: paramNames, Name: route.Name},
+				RouteInfo:          matchedRouteInfo(route, originalPath, r.withHostParams(paramNames)),
+				paramsCount:        len(paramNames),
 				handler:            h,
 				orgRouteInfo:       ri,
 				wrappedHeadHandler: headH,
 			}
-			r.insert(anyKind, path[:i+1], method, rm)
+			r.insert(anyKind, path[:i+1], method, rm, paramConstraints)
 			wasAdded = true
 			break
 		}
 	}
 
 	if !wasAdded {
-		ri = route.ToRouteInfo(paramNames)
+		ri = route.ToRouteInfo(r.withHostParams(paramNames))
 		rm := routeMethod{
-			RouteInfo:          &RouteInfo{Method: method, Path: originalPath, Parameters: paramNames, Name: route.Name},
+			RouteInfo:          matchedRouteInfo(route, originalPath, r.withHostParams(paramNames)),
+			paramsCount:        len(paramNames),
 			handler:            h,
 			orgRouteInfo:       ri,
 			wrappedHeadHandler: headH,
 		}
-		r.insert(staticKind, path, method, rm)
+		r.insert(staticKind, path, method, rm, paramConstraints)
 	}
 
 	r.storeRouteInfo(ri)
@@ -604,6 +683,22 @@ func (r *DefaultRouter) Add(route Route) (RouteInfo, error) {
 	return ri, nil
 }
 
+// matchedRouteInfo creates RouteInfo that is set to the Context when request matches the route.
+func matchedRouteInfo(route Route, path string, paramNames []string) *RouteInfo {
+	return &RouteInfo{
+		Method:     route.Method,
+		Path:       path,
+		Parameters: paramNames,
+		Name:       route.Name,
+		Host:       route.Host,
+		OpenAPI:    route.OpenAPI,
+		Metadata:   maps.Clone(route.Metadata),
+
+		ParamConstraints: pathParamConstraints(path),
+		group:            route.group,
+	}
+}
+
 func normalizePathSlash(path string) string {
 	if path == "" {
 		path = "/"
@@ -623,12 +718,15 @@ func (r *DefaultRouter) storeRouteInfo(ri RouteInfo) {
 	r.routes = append(r.routes, ri)
 }
 
-func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMethod) {
+// insert adds route method to the tree node for given path. Constraints are constraints of path params in the path
+// by their position. Param nodes with different constraints are added as separate param nodes of the same parent.
+func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMethod, constraints []*paramConstraint) {
 	if len(ri.Parameters) > r.maxPathParamsLength {
 		r.maxPathParamsLength = len(ri.Parameters)
 	}
 	currentNode := r.tree // Current node as root
 	search := path
+	paramIndex := 0
 
 	for {
 		searchLen := len(search)
@@ -647,7 +745,7 @@ func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMetho
 			if ri.handler != nil {
 				currentNode.kind = t
 				currentNode.setHandler(method, &ri)
-				currentNode.paramsCount = len(ri.Parameters)
+				currentNode.paramsCount = ri.paramsCount
 				currentNode.originalPath = ri.Path
 			}
 			currentNode.refreshLeaf()
@@ -673,8 +771,8 @@ func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMetho
 			for _, child := range currentNode.staticChildren {
 				child.parent = n
 			}
-			if currentNode.paramChild != nil {
-				currentNode.paramChild.parent = n
+			for child := currentNode.paramChild; child != nil; child = child.nextParam {
+				child.parent = n
 			}
 			if currentNode.anyChild != nil {
 				currentNode.anyChild.parent = n
@@ -702,7 +800,7 @@ func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMetho
 				currentNode.kind = t
 				if ri.handler != nil {
 					currentNode.setHandler(method, &ri)
-					currentNode.paramsCount = len(ri.Parameters)
+					currentNode.paramsCount = ri.paramsCount
 					currentNode.originalPath = ri.Path
 				}
 			} else {
@@ -710,7 +808,7 @@ func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMetho
 				n = newNode(t, search[lcpLen:], currentNode, nil, new(routeMethods), 0, ri.Path, nil, nil)
 				if ri.handler != nil {
 					n.setHandler(method, &ri)
-					n.paramsCount = len(ri.Parameters)
+					n.paramsCount = ri.paramsCount
 				}
 				// Only Static children could reach here
 				currentNode.addStaticChild(n)
@@ -718,9 +816,24 @@ func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMetho
 			currentNode.refreshLeaf()
 		} else if lcpLen < searchLen {
 			search = search[lcpLen:]
-			c := currentNode.findChildWithLabel(search[0])
+			var constraint *paramConstraint
+			if search[0] == paramLabel && paramIndex < len(constraints) {
+				constraint = constraints[paramIndex]
+			}
+			c := currentNode.findStaticChild(search[0])
+			if c == nil {
+				switch search[0] {
+				case paramLabel:
+					c = currentNode.findParamChild(constraint)
+				case anyLabel:
+					c = currentNode.anyChild
+				}
+			}
 			if c != nil {
 				// Go deeper
+				if c.kind == paramKind {
+					paramIndex++
+				}
 				currentNode = c
 				continue
 			}
@@ -728,13 +841,14 @@ func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMetho
 			n := newNode(t, search, currentNode, nil, new(routeMethods), 0, ri.Path, nil, nil)
 			if ri.handler != nil {
 				n.setHandler(method, &ri)
-				n.paramsCount = len(ri.Parameters)
+				n.paramsCount = ri.paramsCount
 			}
 			switch t {
 			case staticKind:
 				currentNode.addStaticChild(n)
 			case paramKind:
-				currentNode.paramChild = n
+				n.paramConstraint = constraint
+				currentNode.addParamChild(n)
 			case anyKind:
 				currentNode.anyChild = n
 			}
@@ -743,7 +857,7 @@ func (r *DefaultRouter) insert(t kind, path string, method string, ri routeMetho
 			// Node already exists
 			if ri.handler != nil {
 				currentNode.setHandler(method, &ri)
-				currentNode.paramsCount = len(ri.Parameters)
+				currentNode.paramsCount = ri.paramsCount
 				currentNode.originalPath = ri.Path
 			}
 		}
@@ -810,17 +924,34 @@ func (n *node) findStaticChild(l byte) *node {
 	return nil
 }
 
-func (n *node) findChildWithLabel(l byte) *node {
-	if c := n.findStaticChild(l); c != nil {
-		return c
+// findParamChild returns param child with given constraint.
+func (n *node) findParamChild(constraint *paramConstraint) *node {
+	for c := n.paramChild; c != nil; c = c.nextParam {
+		if c.paramConstraint.equal(constraint) {
+			return c
+		}
 	}
-	if l == paramLabel {
-		return n.paramChild
+	return nil
+}
+
+// addParamChild adds param child after existing param children, so it is tried last during routing. Constrained param
+// child is added before unconstrained param child so values not satisfying the constraint are matched by the latter.
+func (n *node) addParamChild(c *node) {
+	next := &n.paramChild
+	for *next != nil && (c.paramConstraint == nil || (*next).paramConstraint != nil) {
+		next = &(*next).nextParam
 	}
-	if l == anyLabel {
-		return n.anyChild
+	c.nextParam = *next
+	*next = c
+}
+
+func (n *node) removeParamChild(c *node) {
+	for next := &n.paramChild; *next != nil; next = &(*next).nextParam {
+		if *next == c {
+			*next = c.nextParam
+			return
+		}
 	}
-	return nil
 }
 
 func (n *node) setHandler(method string, r *routeMethod) {
@@ -828,6 +959,18 @@ func (n *node) setHandler(method string, r *routeMethod) {
 	n.isHandler = n.methods.isHandler()
 }
 
+// preferGroupMethodNotAllowed reports whether request should be handled as 405 instead of group 404 route. This is
+// the case when best matching node has routes of a group with its own error handler or MethodNotAllowedHandler but
+// not for the request method. OPTIONS requests are still routed to 404 route so group middlewares (i.e. CORS) can
+// handle preflight requests.
+func preferGroupMethodNotAllowed(bestMatch *node, method string) bool {
+	if bestMatch == nil || !bestMatch.isHandler || method == http.MethodOptions {
+		return false
+	}
+	g := bestMatch.methods.group
+	return g != nil && (g.methodNotAllowedChain != nil || g.httpErrorHandler != nil)
+}
+
 // Note: notFoundRouteInfo exists to avoid allocations when setting 404 RouteInfo to Context
 var notFoundRouteInfo = &RouteInfo{
 	Method:     "",
@@ -884,9 +1027,18 @@ var optionsMethodHandler = func(c *Context) error {
 // - Reset it `Context#Reset()`
 // - Return it `Echo#ReleaseContext()`.
 func (r *DefaultRouter) Route(c *Context) HandlerFunc {
+	if r.hosts == nil {
+		return r.route(c, nil)
+	}
+	return r.routeHost(c)
+}
+
+// route searches the router tree for request path. hostValues are values of the host pattern parameters when
+// router is the host specific router.
+func (r *DefaultRouter) route(c *Context, hostValues PathValues) HandlerFunc {
 	pathValues := c.PathValues()
 	if cap(pathValues) < r.maxPathParamsLength {
-		pathValues = make(PathValues, 0, r.maxPathParamsLength)
+		pathValues = make(PathValues, r.maxPathParamsLength)
 	} else {
 		pathValues = pathValues[0:cap(pathValues)] // resize slice to maximum capacity so we can index set values
 	}
@@ -908,6 +1060,8 @@ func (r *DefaultRouter) Route(c *Context) HandlerFunc {
 		search      = path
 		searchIndex = 0
 		paramIndex  int // Param counter
+		// nextParamChild is param node to check next when backtracking from param node that has next param sibling
+		nextParamChild *node
 	)
 
 	// Backtracking is needed when a dead end (leaf node) is reached in the router tree.
@@ -920,9 +1074,12 @@ func (r *DefaultRouter) Route(c *Context) HandlerFunc {
 		currentNode = previous.parent
 		valid = currentNode != nil
 
-		// Next node type by priority
+		// Next node type by priority. Param node siblings with different constraints are checked before any node.
 		if previous.kind == anyKind {
 			nextNodeKind = staticKind
+		} else if previous.kind == paramKind && previous.nextParam != nil {
+			nextNodeKind = paramKind
+			nextParamChild = previous.nextParam
 		} else {
 			nextNodeKind = previous.kind + 1
 		}
@@ -1015,19 +1172,35 @@ func (r *DefaultRouter) Route(c *Context) HandlerFunc {
 		}
 
 	Param:
-		// Param node
-		if child := currentNode.paramChild; search != "" && child != nil {
-			currentNode = child
+		// Param node. When node has several param children with different constraints, they are checked in the order
+		// they were added, unconstrained param child last.
+		paramChild := currentNode.paramChild
+		if nextParamChild != nil {
+			paramChild, nextParamChild = nextParamChild, nil
+		}
+		if child := paramChild; search != "" && child != nil {
 			i := 0
 			l := len(search)
-			if currentNode.isLeaf {
-				// when param node does not have any children (path param is last piece of route path) then param node should
-				// act similarly to any node - consider all remaining search as match
-				i = l
-			} else {
-				for ; i < l && search[i] != '/'; i++ {
+			for ; child != nil; child = child.nextParam {
+				i = 0
+				if child.isLeaf {
+					// when param node does not have any children (path param is last piece of route path) then param node should
+					// act similarly to any node - consider all remaining search as match
+					i = l
+				} else {
+					for ; i < l && search[i] != '/'; i++ {
+					}
+				}
+				if child.paramConstraint == nil || child.paramConstraint.matchValue(search[:i], r.unescapePathParamValues) {
+					break
 				}
 			}
+			if child == nil {
+				// value does not satisfy constraints of any param node. Continue with any node or backtrack as if there
+				// would be no param node at all
+				goto Any
+			}
+			currentNode = child
 
 			pathValues[paramIndex].Value = search[:i]
 			paramIndex++
@@ -1056,7 +1229,9 @@ func (r *DefaultRouter) Route(c *Context) HandlerFunc {
 				previousBestMatchNode = currentNode
 			}
 			if currentNode.methods.notFoundHandler != nil {
-				matchedRouteMethod = currentNode.methods.notFoundHandler
+				if !preferGroupMethodNotAllowed(previousBestMatchNode, req.Method) {
+					matchedRouteMethod = currentNode.methods.notFoundHandler
+				}
 				break
 			}
 		}
@@ -1085,6 +1260,7 @@ func (r *DefaultRouter) Route(c *Context) HandlerFunc {
 	var rHandler HandlerFunc
 	var rPath string
 	var rInfo *RouteInfo
+	var group *Group
 	if matchedRouteMethod != nil {
 		rHandler = matchedRouteMethod.handler
 		if req.Method == http.MethodHead && matchedRouteMethod.wrappedHeadHandler != nil {
@@ -1109,9 +1285,13 @@ func (r *DefaultRouter) Route(c *Context) HandlerFunc {
 			rHandler = matchedRouteMethod.handler
 		} else if currentNode.isHandler {
 			rInfo = methodNotAllowedRouteInfo
+			group = currentNode.methods.group
 
 			c.Set(ContextKeyHeaderAllow, currentNode.methods.allowHeader)
 			rHandler = r.methodNotAllowedHandler
+			if group != nil && group.methodNotAllowedChain != nil {
+				rHandler = group.methodNotAllowedChain
+			}
 			if req.Method == http.MethodOptions {
 				rHandler = r.optionsMethodHandler
 			}
@@ -1120,8 +1300,8 @@ func (r *DefaultRouter) Route(c *Context) HandlerFunc {
 
 	pathValues = pathValues[0:currentNode.paramsCount]
 	if matchedRouteMethod != nil {
-		for i, name := range matchedRouteMethod.Parameters {
-			pathValues[i].Name = name
+		for i := range pathValues {
+			pathValues[i].Name = matchedRouteMethod.Parameters[i]
 		}
 	}
 
@@ -1134,8 +1314,16 @@ func (r *DefaultRouter) Route(c *Context) HandlerFunc {
 			}
 		}
 	}
+	// host parameters are placed after path parameters
+	pathValues = append(pathValues, hostValues...)
 
 	c.InitializeRoute(rInfo, &pathValues)
+	if group != nil {
+		c.group = group // 405 route info is shared by all routes and does not know the group
+	}
+	if c.mountPrefix != "" && rPath != "" {
+		rPath = c.mountPrefix + rPath // Echo is mounted under prefix that was stripped from the request path for routing
+	}
 	c.SetPath(rPath)          // after InitializeRoute so we would not accidentally change `notFoundRouteInfo` or `methodNotAllowedRouteInfo` Path
 	c.request.Pattern = rPath // help standard library based middlewares. This is a deliberate choice not to call `request.SetPathValue` for params.
 	return rHandler
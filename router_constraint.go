@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Path parameter constraints restrict values that path parameter can match. Constraint is written in angle brackets
+// after the parameter name, for example `/users/:id<int>` or `/articles/:slug<[a-z0-9-]+>`. When value does not satisfy
+// the constraint router continues searching as if the parameter node did not exist (falls through to other routes
+// or 404).
+//
+// Routes can have parameters with different constraints at the same position, for example `/users/:id<int>` and
+// `/users/:slug<alpha>`. Constrained parameters are checked in the order their routes were added and unconstrained
+// parameter (`/users/:name`) is always checked last.
+//
+// Constraint can not contain `<` or `>` characters.
+//
+// Built-in constraints are:
+//   - `int` - optional minus sign followed by digits
+//   - `uint` - digits
+//   - `alpha` - ASCII letters
+//   - `alnum` - ASCII letters and digits
+//   - `uuid` - UUID in canonical textual form (8-4-4-4-12 hex digits)
+//
+// Any other constraint is treated as regular expression that must match the whole value.
+const (
+	ParamConstraintInt   = "int"
+	ParamConstraintUint  = "uint"
+	ParamConstraintAlpha = "alpha"
+	ParamConstraintAlnum = "alnum"
+	ParamConstraintUUID  = "uuid"
+)
+
+var builtinParamConstraints = map[string]func(string) bool{
+	ParamConstraintInt:   isInteger,
+	ParamConstraintUint:  isDigits,
+	ParamConstraintAlpha: isAlpha,
+	ParamConstraintAlnum: isAlnum,
+	ParamConstraintUUID:  isUUID,
+}
+
+// paramConstraintCache holds compiled constraints by their expression so routes using same constraint share matcher.
+var paramConstraintCache sync.Map
+
+type paramConstraint struct {
+	match func(string) bool
+	expr  string
+}
+
+func compileParamConstraint(expr string) (*paramConstraint, error) {
+	if pc, ok := paramConstraintCache.Load(expr); ok {
+		return pc.(*paramConstraint), nil
+	}
+	pc := &paramConstraint{expr: expr}
+	if m, ok := builtinParamConstraints[expr]; ok {
+		pc.match = m
+	} else {
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return nil, err
+		}
+		pc.match = re.MatchString
+	}
+	actual, _ := paramConstraintCache.LoadOrStore(expr, pc)
+	return actual.(*paramConstraint), nil
+}
+
+func (pc *paramConstraint) equal(other *paramConstraint) bool {
+	if pc == nil || other == nil {
+		return pc == other
+	}
+	return pc.expr == other.expr
+}
+
+func (pc *paramConstraint) matchValue(value string, unescape bool) bool {
+	if unescape {
+		if v, err := url.PathUnescape(value); err == nil {
+			value = v
+		}
+	}
+	return pc.match(value)
+}
+
+// paramSegmentEnd returns index where path parameter segment starting at index `i` ends (next slash or end of path).
+// Slashes inside constraint angle brackets (`:name<[^/]+>`) do not end the segment. Constraint ends at the first `>`.
+func paramSegmentEnd(path string, i int) int {
+	inConstraint := false
+	for ; i < len(path); i++ {
+		switch path[i] {
+		case '<':
+			inConstraint = true
+		case '>':
+			inConstraint = false
+		case '/':
+			if !inConstraint {
+				return i
+			}
+		}
+	}
+	return i
+}
+
+// checkParamConstraintSyntax checks that constraints of path parameters are enclosed in angle brackets at the end of
+// the segment and do not contain angle brackets themselves.
+func checkParamConstraintSyntax(path string) error {
+	for i, l := 0, len(path); i < l; i++ {
+		if path[i] != paramLabel || (i > 0 && path[i-1] == '\\') {
+			continue
+		}
+		j := i + 1
+		i = paramSegmentEnd(path, i)
+		segment := path[j:i]
+		idx := strings.IndexAny(segment, "<>")
+		if idx == -1 {
+			continue
+		}
+		name := segment[:idx]
+		if segment[idx] == '>' || segment[len(segment)-1] != '>' {
+			return fmt.Errorf("invalid constraint for path parameter %q: constraint must be enclosed in '<' and '>' at the end of the segment", name)
+		}
+		if strings.ContainsAny(segment[idx+1:len(segment)-1], "<>") {
+			return fmt.Errorf("invalid constraint for path parameter %q: constraint can not contain '<' or '>'", name)
+		}
+	}
+	return nil
+}
+
+// splitParamConstraint splits path parameter segment (without leading colon) `id<int>` to name and constraint.
+func splitParamConstraint(segment string) (name string, constraint string) {
+	idx := strings.IndexByte(segment, '<')
+	if idx == -1 || segment[len(segment)-1] != '>' {
+		return segment, ""
+	}
+	return segment[:idx], segment[idx+1 : len(segment)-1]
+}
+
+// pathParamConstraints returns path parameter constraints of the route path by parameter name. Returns nil when path
+// has no constrained parameters.
+func pathParamConstraints(path string) map[string]string {
+	var result map[string]string
+	for i, l := 0, len(path); i < l; i++ {
+		if path[i] != paramLabel || (i > 0 && path[i-1] == '\\') {
+			continue
+		}
+		j := i + 1
+		i = paramSegmentEnd(path, i)
+		name, constraint := splitParamConstraint(path[j:i])
+		if constraint == "" {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[name] = constraint
+	}
+	return result
+}
+
+func isASCIIMatch(v string, fn func(c byte) bool) bool {
+	if v == "" {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if !fn(v[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isInteger(v string) bool {
+	if v != "" && v[0] == '-' {
+		v = v[1:]
+	}
+	return isDigits(v)
+}
+
+func isDigits(v string) bool {
+	return isASCIIMatch(v, isASCIIDigit)
+}
+
+func isAlpha(v string) bool {
+	return isASCIIMatch(v, isASCIILetter)
+}
+
+func isAlnum(v string) bool {
+	return isASCIIMatch(v, isASCIIAlnum)
+}
+
+func isASCIIAlnum(c byte) bool {
+	return isASCIILetter(c) || isASCIIDigit(c)
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isUUID(v string) bool {
+	if len(v) != 36 {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !isASCIIDigit(c) && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
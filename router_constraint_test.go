@@ -0,0 +1,427 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultRouter_ParamConstraints(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		whenURL          string
+		expectRoute      any
+		expectPathValues PathValues
+	}{
+		{
+			name:             "ok, int constraint matches",
+			whenURL:          "/users/123",
+			expectRoute:      "/users/:id<int>",
+			expectPathValues: PathValues{{Name: "id", Value: "123"}},
+		},
+		{
+			name:             "ok, negative int",
+			whenURL:          "/users/-1",
+			expectRoute:      "/users/:id<int>",
+			expectPathValues: PathValues{{Name: "id", Value: "-1"}},
+		},
+		{
+			name:             "ok, int constraint fails, falls through to any route",
+			whenURL:          "/users/abc",
+			expectRoute:      "/users/*",
+			expectPathValues: PathValues{{Name: "*", Value: "abc"}},
+		},
+		{
+			name:             "ok, static route has priority",
+			whenURL:          "/users/new",
+			expectRoute:      "/users/new",
+			expectPathValues: PathValues{},
+		},
+		{
+			name:             "ok, constraint on non-leaf param",
+			whenURL:          "/users/5/files",
+			expectRoute:      "/users/:id<int>/files",
+			expectPathValues: PathValues{{Name: "id", Value: "5"}},
+		},
+		{
+			name:             "ok, regex constraint",
+			whenURL:          "/articles/hello-world-2",
+			expectRoute:      "/articles/:slug<[a-z0-9-]+>",
+			expectPathValues: PathValues{{Name: "slug", Value: "hello-world-2"}},
+		},
+		{
+			name:             "nok, regex constraint does not match and no fallback",
+			whenURL:          "/articles/Hello_World",
+			expectRoute:      nil,
+			expectPathValues: PathValues{},
+		},
+		{
+			name:             "ok, regex constraint containing slash",
+			whenURL:          "/dates/2024/01",
+			expectRoute:      "/dates/:date<[0-9]{4}/[0-9]{2}>",
+			expectPathValues: PathValues{{Name: "date", Value: "2024/01"}},
+		},
+		{
+			name:             "ok, uuid constraint",
+			whenURL:          "/files/0e8c7b5e-3f1a-4b9c-9a61-6a4b5c2d7e8f",
+			expectRoute:      "/files/:uuid<uuid>",
+			expectPathValues: PathValues{{Name: "uuid", Value: "0e8c7b5e-3f1a-4b9c-9a61-6a4b5c2d7e8f"}},
+		},
+		{
+			name:             "nok, uuid constraint",
+			whenURL:          "/files/0e8c7b5e",
+			expectRoute:      nil,
+			expectPathValues: PathValues{},
+		},
+		{
+			name:             "ok, constraint fails and backtracks to parent any route",
+			whenURL:          "/orders/x/items",
+			expectRoute:      "/orders*",
+			expectPathValues: PathValues{{Name: "*", Value: "/x/items"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+
+			for _, path := range []string{
+				"/users/new",
+				"/users/:id<int>",
+				"/users/:id<int>/files",
+				"/users/*",
+				"/articles/:slug<[a-z0-9-]+>",
+				"/dates/:date<[0-9]{4}/[0-9]{2}>",
+				"/files/:uuid<uuid>",
+				"/orders*",
+				"/orders/:id<uint>/items",
+			} {
+				_, err := e.AddRoute(Route{Method: http.MethodGet, Path: path, Handler: handlerFunc})
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			c := e.NewContext(req, nil)
+			handler := e.router.Route(c)
+			_ = handler(c)
+
+			assert.Equal(t, tc.expectRoute, c.Get("path"))
+			assert.Equal(t, tc.expectPathValues, c.PathValues())
+		})
+	}
+}
+
+func TestDefaultRouter_ParamConstraintsRouteInfo(t *testing.T) {
+	r := NewRouter(RouterConfig{})
+
+	ri, err := r.Add(Route{Method: http.MethodGet, Path: "/users/:id<int>/files/:name", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"id", "name"}, ri.Parameters)
+	assert.Equal(t, map[string]string{"id": "int"}, ri.ParamConstraints)
+	assert.Equal(t, "/users/:id<int>/files/:name", ri.Path)
+}
+
+func TestDefaultRouter_ParamConstraintsAddError(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		givenPath string
+		whenPath  string
+		expectErr string
+	}{
+		{
+			name:      "nok, invalid regex",
+			whenPath:  "/users/:id<[0-9>",
+			expectErr: "GET /users/:id<[0-9>: invalid constraint for path parameter \"id\": error parsing regexp: missing closing ]: `[0-9)$`",
+		},
+		{
+			name:      "nok, constraint containing '<'",
+			whenPath:  "/users/:id<(?P<n>[0-9]+)>/files",
+			expectErr: "GET /users/:id<(?P<n>[0-9]+)>/files: invalid constraint for path parameter \"id\": constraint can not contain '<' or '>'",
+		},
+		{
+			name:      "nok, constraint containing '>'",
+			whenPath:  "/users/:id<[^>]+>",
+			expectErr: "GET /users/:id<[^>]+>: invalid constraint for path parameter \"id\": constraint can not contain '<' or '>'",
+		},
+		{
+			name:      "nok, text after constraint",
+			whenPath:  "/users/:id<int>x/files",
+			expectErr: "GET /users/:id<int>x/files: invalid constraint for path parameter \"id\": constraint must be enclosed in '<' and '>' at the end of the segment",
+		},
+		{
+			name:      "nok, constraint is not closed",
+			whenPath:  "/users/:id<[^/]+",
+			expectErr: "GET /users/:id<[^/]+: invalid constraint for path parameter \"id\": constraint must be enclosed in '<' and '>' at the end of the segment",
+		},
+		{
+			name:      "ok, different constraint",
+			givenPath: "/users/:id<int>",
+			whenPath:  "/users/:name<alpha>/files",
+		},
+		{
+			name:      "ok, unconstrained param next to constrained param",
+			givenPath: "/users/:id<int>",
+			whenPath:  "/users/:id",
+		},
+		{
+			name:      "ok, same constraint",
+			givenPath: "/users/:id<int>",
+			whenPath:  "/users/:id<int>/files",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRouter(RouterConfig{})
+			if tc.givenPath != "" {
+				_, err := r.Add(Route{Method: http.MethodGet, Path: tc.givenPath, Handler: handlerFunc})
+				assert.NoError(t, err)
+			}
+
+			_, err := r.Add(Route{Method: http.MethodGet, Path: tc.whenPath, Handler: handlerFunc})
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDefaultRouter_ParamConstraintsSiblings(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		whenURL          string
+		expectRoute      any
+		expectPathValues PathValues
+	}{
+		{
+			name:             "ok, first param node matches",
+			whenURL:          "/users/123",
+			expectRoute:      "/users/:id<int>",
+			expectPathValues: PathValues{{Name: "id", Value: "123"}},
+		},
+		{
+			name:             "ok, second param node matches",
+			whenURL:          "/users/jon",
+			expectRoute:      "/users/:slug<alpha>",
+			expectPathValues: PathValues{{Name: "slug", Value: "jon"}},
+		},
+		{
+			name:             "ok, unconstrained param node added last matches the rest",
+			whenURL:          "/users/jon-1",
+			expectRoute:      "/users/:name",
+			expectPathValues: PathValues{{Name: "name", Value: "jon-1"}},
+		},
+		{
+			name:             "ok, non-leaf param node with matching constraint",
+			whenURL:          "/users/jon/files",
+			expectRoute:      "/users/:slug<alpha>/files",
+			expectPathValues: PathValues{{Name: "slug", Value: "jon"}},
+		},
+		{
+			name:             "ok, backtracks to next param node when first matching one is dead end",
+			whenURL:          "/items/1/b",
+			expectRoute:      "/items/:name/b",
+			expectPathValues: PathValues{{Name: "name", Value: "1"}},
+		},
+		{
+			name:             "ok, siblings of nested param",
+			whenURL:          "/users/jon/files/42",
+			expectRoute:      "/users/:slug<alpha>/files/:id<uint>",
+			expectPathValues: PathValues{{Name: "slug", Value: "jon"}, {Name: "id", Value: "42"}},
+		},
+		{
+			name:             "ok, siblings of nested param, second matches",
+			whenURL:          "/users/jon/files/readme",
+			expectRoute:      "/users/:slug<alpha>/files/:file",
+			expectPathValues: PathValues{{Name: "slug", Value: "jon"}, {Name: "file", Value: "readme"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+
+			for _, path := range []string{
+				"/users/:id<int>",
+				"/users/:slug<alpha>",
+				"/users/:slug<alpha>/files",
+				"/users/:slug<alpha>/files/:id<uint>",
+				"/users/:slug<alpha>/files/:file",
+				"/users/:name",
+				"/items/:id<int>/a",
+				"/items/:name/b",
+			} {
+				_, err := e.AddRoute(Route{Method: http.MethodGet, Path: path, Handler: handlerFunc})
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.whenURL, nil)
+			c := e.NewContext(req, nil)
+			handler := e.router.Route(c)
+			_ = handler(c)
+
+			assert.Equal(t, tc.expectRoute, c.Get("path"))
+			assert.Equal(t, tc.expectPathValues, c.PathValues())
+		})
+	}
+}
+
+func TestDefaultRouter_ParamConstraintsSiblingsOrder(t *testing.T) {
+	e := New()
+
+	// unconstrained param is checked last regardless of the order routes are added
+	for _, path := range []string{"/users/:name", "/users/:id<int>", "/users/:slug<alpha>"} {
+		_, err := e.AddRoute(Route{Method: http.MethodGet, Path: path, Handler: handlerFunc})
+		assert.NoError(t, err)
+	}
+
+	for whenURL, expectRoute := range map[string]string{
+		"/users/123":   "/users/:id<int>",
+		"/users/jon":   "/users/:slug<alpha>",
+		"/users/jon-1": "/users/:name",
+	} {
+		req := httptest.NewRequest(http.MethodGet, whenURL, nil)
+		c := e.NewContext(req, nil)
+		_ = e.router.Route(c)(c)
+		assert.Equal(t, expectRoute, c.Get("path"), whenURL)
+	}
+}
+
+func TestDefaultRouter_ParamConstraintsSiblingsRemove(t *testing.T) {
+	e := New()
+
+	for _, path := range []string{"/users/:id<int>", "/users/:slug<alpha>", "/users/:name"} {
+		_, err := e.AddRoute(Route{Method: http.MethodGet, Path: path, Handler: handlerFunc})
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, e.router.Remove(http.MethodGet, "/users/:slug<alpha>"))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/jon", nil)
+	c := e.NewContext(req, nil)
+	_ = e.router.Route(c)(c)
+	assert.Equal(t, "/users/:name", c.Get("path"))
+
+	req = httptest.NewRequest(http.MethodGet, "/users/123", nil)
+	c = e.NewContext(req, nil)
+	_ = e.router.Route(c)(c)
+	assert.Equal(t, "/users/:id<int>", c.Get("path"))
+}
+
+func TestDefaultRouter_ParamConstraintsAfterRemove(t *testing.T) {
+	e := New()
+
+	_, err := e.AddRoute(Route{Method: http.MethodGet, Path: "/users/:id<int>", Handler: handlerFunc})
+	assert.NoError(t, err)
+	assert.NoError(t, e.router.Remove(http.MethodGet, "/users/:id<int>"))
+
+	_, err = e.AddRoute(Route{Method: http.MethodGet, Path: "/users/:name", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/abc", nil)
+	c := e.NewContext(req, nil)
+	_ = e.router.Route(c)(c)
+
+	assert.Equal(t, "/users/:name", c.Get("path"))
+}
+
+func TestDefaultRouter_ParamConstraintsUnescape(t *testing.T) {
+	e := New()
+	r := NewRouter(RouterConfig{UnescapePathParamValues: true})
+	e.router = r
+	e.contextPathParamAllocSize.Store(1)
+
+	_, err := r.Add(Route{Method: http.MethodGet, Path: "/tags/:tag<[a-z ]+>", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/tags/a%20b", nil)
+	c := e.NewContext(req, nil)
+	_ = r.Route(c)
+
+	assert.Equal(t, "/tags/:tag<[a-z ]+>", c.Path())
+	assert.Equal(t, PathValues{{Name: "tag", Value: "a b"}}, c.PathValues())
+}
+
+func TestRoutes_ReverseParamConstraints(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		whenArgs  []any
+		expect    string
+		expectErr string
+	}{
+		{
+			name:     "ok",
+			whenArgs: []any{10, "abc"},
+			expect:   "/users/10/files/abc",
+		},
+		{
+			name:     "ok, partial args",
+			whenArgs: []any{10},
+			expect:   "/users/10/files/:name<[a-z]+>",
+		},
+		{
+			name:      "nok, int constraint",
+			whenArgs:  []any{"x", "abc"},
+			expectErr: `path parameter "id" value "x" does not satisfy constraint "int"`,
+		},
+		{
+			name:      "nok, regex constraint",
+			whenArgs:  []any{1, "ABC"},
+			expectErr: `path parameter "name" value "ABC" does not satisfy constraint "[a-z]+"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRouter(RouterConfig{})
+			_, err := r.Add(Route{Method: http.MethodGet, Path: "/users/:id<int>/files/:name<[a-z]+>", Name: "file", Handler: handlerFunc})
+			assert.NoError(t, err)
+
+			reversed, err := r.Routes().Reverse("file", tc.whenArgs...)
+			assert.Equal(t, tc.expect, reversed)
+			assert.Equal(t, tc.expect, r.Routes()[0].Reverse(tc.whenArgs...))
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParamConstraintBuiltins(t *testing.T) {
+	var testCases = []struct {
+		constraint string
+		when       string
+		expect     bool
+	}{
+		{constraint: ParamConstraintInt, when: "0", expect: true},
+		{constraint: ParamConstraintInt, when: "-", expect: false},
+		{constraint: ParamConstraintInt, when: "", expect: false},
+		{constraint: ParamConstraintUint, when: "-1", expect: false},
+		{constraint: ParamConstraintUint, when: "42", expect: true},
+		{constraint: ParamConstraintAlpha, when: "abcXYZ", expect: true},
+		{constraint: ParamConstraintAlpha, when: "abc1", expect: false},
+		{constraint: ParamConstraintAlnum, when: "abc1", expect: true},
+		{constraint: ParamConstraintAlnum, when: "abc-1", expect: false},
+		{constraint: ParamConstraintUUID, when: "0E8C7B5E-3F1A-4B9C-9A61-6A4B5C2D7E8F", expect: true},
+		{constraint: ParamConstraintUUID, when: "0e8c7b5e-3f1a-4b9c-9a61-6a4b5c2d7e8g", expect: false},
+		{constraint: "a|b", when: "ab", expect: false},
+		{constraint: "a|b", when: "b", expect: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.constraint+"_"+tc.when, func(t *testing.T) {
+			pc, err := compileParamConstraint(tc.constraint)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, pc.match(tc.when))
+		})
+	}
+}
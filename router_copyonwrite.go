@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// CopyOnWriteRouter is concurrency safe Router which routes can be added/removed while http.Server is serving
+// requests. Every change builds a new immutable DefaultRouter and atomically swaps it with the current one, so routing
+// never takes a lock and in-flight requests keep using the router snapshot they were routed with.
+//
+// Changes are relatively expensive (all routes are re-added to the new router) so prefer Update to apply multiple
+// changes in one go, i.e. to register all routes at startup.
+type CopyOnWriteRouter struct {
+	current atomic.Pointer[DefaultRouter]
+	// mu serializes writers. Readers never take it.
+	mu     sync.Mutex
+	config RouterConfig
+
+	// routes are all routes added to the router in order they were added. Used to build the router on change. Only
+	// appended to, a new slice is allocated when routes are removed.
+	routes []Route
+}
+
+// RouterTx is a batch of route changes applied atomically with CopyOnWriteRouter.Update.
+type RouterTx struct {
+	config RouterConfig
+	// router is new router built from routes. It is not visible to readers until the transaction is committed.
+	router *DefaultRouter
+	routes []Route
+}
+
+// NewCopyOnWriteRouter creates new CopyOnWriteRouter instance.
+func NewCopyOnWriteRouter(config RouterConfig) *CopyOnWriteRouter {
+	r := &CopyOnWriteRouter{config: config}
+	r.current.Store(NewRouter(config))
+	return r
+}
+
+// Route searches current router snapshot for matching route and applies it to the given context.
+func (r *CopyOnWriteRouter) Route(c *Context) HandlerFunc {
+	return r.current.Load().Route(c)
+}
+
+// Routes returns information about all registered routes.
+func (r *CopyOnWriteRouter) Routes() Routes {
+	return r.current.Load().Routes().Clone()
+}
+
+// Add registers a new route with the router.
+func (r *CopyOnWriteRouter) Add(route Route) (RouteInfo, error) {
+	var ri RouteInfo
+	err := r.Update(func(tx *RouterTx) error {
+		var err error
+		ri, err = tx.Add(route)
+		return err
+	})
+	return ri, err
+}
+
+// Remove removes route from the router.
+func (r *CopyOnWriteRouter) Remove(method string, path string) error {
+	return r.Update(func(tx *RouterTx) error {
+		return tx.Remove(method, path)
+	})
+}
+
+// Update applies all changes done with RouterTx as a single atomic change. New router is fully built before it
+// replaces the current one. When given function returns an error none of the changes are applied and the error is
+// returned.
+//
+// Note: Routes added with the transaction do not go through Echo.AddRoute so Echo.OnAddRoute is not called for them.
+func (r *CopyOnWriteRouter) Update(fn func(tx *RouterTx) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// current router can not be changed in place as it is used by readers, so the transaction starts with a new one
+	router, err := buildRouter(r.config, r.routes)
+	if err != nil {
+		return err
+	}
+	tx := &RouterTx{
+		config: r.config,
+		router: router,
+		// transaction must not append to the backing array shared with r.routes when it is rolled back
+		routes: r.routes[:len(r.routes):len(r.routes)],
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	r.routes = tx.routes
+	r.current.Store(tx.router)
+	return nil
+}
+
+func buildRouter(config RouterConfig, routes []Route) (*DefaultRouter, error) {
+	router := NewRouter(config)
+	for _, route := range routes {
+		if _, err := router.Add(route); err != nil {
+			return nil, err
+		}
+	}
+	return router, nil
+}
+
+// Add registers a new route within the transaction.
+func (tx *RouterTx) Add(route Route) (RouteInfo, error) {
+	ri, err := tx.router.Add(route)
+	if err != nil {
+		return RouteInfo{}, err
+	}
+	tx.routes = append(tx.routes, route)
+	return ri, nil
+}
+
+// Remove removes route within the transaction. Routes without host are searched first and then host routes. Router of
+// the transaction is rebuilt without the removed route.
+func (tx *RouterTx) Remove(method string, path string) error {
+	index := -1
+	for i, route := range tx.routes {
+		if route.Method != method || normalizePathSlash(route.Path) != normalizePathSlash(path) {
+			continue
+		}
+		if route.Host == "" {
+			index = i
+			break
+		}
+		if index == -1 {
+			index = i
+		}
+	}
+	if index == -1 {
+		return errors.New("could not find route to remove by given path and method")
+	}
+
+	// remove also routes that were overwritten by the removed route. Routes are copied to a new slice as the
+	// backing array is shared with CopyOnWriteRouter.
+	removed := tx.routes[index]
+	routes := make([]Route, 0, len(tx.routes)-1)
+	for _, route := range tx.routes {
+		if route.Method == removed.Method && route.Path == removed.Path && route.Host == removed.Host {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	router, err := buildRouter(tx.config, routes)
+	if err != nil {
+		return err
+	}
+	tx.routes = routes
+	tx.router = router
+	return nil
+}
+
+// Routes returns information about all routes registered within the transaction.
+func (tx *RouterTx) Routes() Routes {
+	return tx.router.Routes().Clone()
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyOnWriteRouter_AddRemove(t *testing.T) {
+	router := NewCopyOnWriteRouter(RouterConfig{})
+
+	ri, err := router.Add(Route{Method: http.MethodGet, Path: "/users/:id", Handler: handlerFunc})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id"}, ri.Parameters)
+	_, err = router.Add(Route{Method: http.MethodGet, Path: "/users", Handler: handlerFunc})
+	assert.NoError(t, err)
+	assert.Len(t, router.Routes(), 2)
+
+	_, err = router.Add(Route{Method: http.MethodGet, Path: "/users", Handler: handlerFunc})
+	assert.EqualError(t, err, "GET /users: adding duplicate route (same method+path) is not allowed")
+	assert.Len(t, router.Routes(), 2)
+
+	assert.NoError(t, router.Remove(http.MethodGet, "/users/:id"))
+	assert.Len(t, router.Routes(), 1)
+	assert.EqualError(t, router.Remove(http.MethodGet, "/users/:id"), "could not find route to remove by given path and method")
+
+	// removed route must not be re-added when router is rebuilt on next change
+	_, err = router.Add(Route{Method: http.MethodPost, Path: "/users", Handler: handlerFunc})
+	assert.NoError(t, err)
+	assert.Equal(t, Routes{
+		{Method: http.MethodGet, Path: "/users", Name: "GET:/users"},
+		{Method: http.MethodPost, Path: "/users", Name: "POST:/users"},
+	}, router.Routes())
+}
+
+func TestCopyOnWriteRouter_snapshotIsBuiltOnChange(t *testing.T) {
+	router := NewCopyOnWriteRouter(RouterConfig{})
+	_, err := router.Add(Route{Method: http.MethodGet, Path: "/users/:uid/files/:fid", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	snapshot := router.current.Load()
+	assert.Len(t, snapshot.Routes(), 1)
+
+	// context path values are allocated by Echo for its own routes. Routes added directly to the router can have more
+	// parameters than the context has capacity for.
+	c := NewContext(httptest.NewRequest(http.MethodGet, "/users/1/files/2", nil), nil)
+	assert.Equal(t, 0, cap(c.PathValues()))
+	assert.NotPanics(t, func() {
+		_ = router.Route(c)
+	})
+	assert.Equal(t, "/users/:uid/files/:fid", c.Path())
+	assert.Equal(t, "1", c.Param("uid"))
+	assert.Equal(t, "2", c.Param("fid"))
+
+	// snapshot is not affected by changes made after it
+	_, err = router.Add(Route{Method: http.MethodGet, Path: "/new", Handler: handlerFunc})
+	assert.NoError(t, err)
+	assert.NoError(t, router.Remove(http.MethodGet, "/users/:uid/files/:fid"))
+	assert.Len(t, snapshot.Routes(), 1)
+	assert.Equal(t, Routes{{Method: http.MethodGet, Path: "/new", Name: "GET:/new"}}, router.Routes())
+}
+
+func TestRouterTx_Routes(t *testing.T) {
+	router := NewCopyOnWriteRouter(RouterConfig{})
+	_, err := router.Add(Route{Method: http.MethodGet, Path: "/a", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	err = router.Update(func(tx *RouterTx) error {
+		assert.Len(t, tx.Routes(), 1)
+		if _, err := tx.Add(Route{Method: http.MethodGet, Path: "/b", Handler: handlerFunc}); err != nil {
+			return err
+		}
+		if err := tx.Remove(http.MethodGet, "/a"); err != nil {
+			return err
+		}
+		assert.Equal(t, Routes{{Method: http.MethodGet, Path: "/b", Name: "GET:/b"}}, tx.Routes())
+		// changes are not visible before the transaction is committed
+		assert.Len(t, router.Routes(), 1)
+		assert.Equal(t, "/a", router.Routes()[0].Path)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Routes{{Method: http.MethodGet, Path: "/b", Name: "GET:/b"}}, router.Routes())
+}
+
+func TestCopyOnWriteRouter_Update(t *testing.T) {
+	router := NewCopyOnWriteRouter(RouterConfig{})
+	_, err := router.Add(Route{Method: http.MethodGet, Path: "/a", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	err = router.Update(func(tx *RouterTx) error {
+		if _, err := tx.Add(Route{Method: http.MethodGet, Path: "/b", Handler: handlerFunc}); err != nil {
+			return err
+		}
+		assert.Len(t, tx.Routes(), 2)
+		return tx.Remove(http.MethodGet, "/a")
+	})
+	assert.NoError(t, err)
+
+	routes := router.Routes()
+	assert.Len(t, routes, 1)
+	assert.Equal(t, "/b", routes[0].Path)
+}
+
+func TestCopyOnWriteRouter_UpdateRollback(t *testing.T) {
+	router := NewCopyOnWriteRouter(RouterConfig{})
+	_, err := router.Add(Route{Method: http.MethodGet, Path: "/a", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	err = router.Update(func(tx *RouterTx) error {
+		if _, err := tx.Add(Route{Method: http.MethodGet, Path: "/b", Handler: handlerFunc}); err != nil {
+			return err
+		}
+		if err := tx.Remove(http.MethodGet, "/a"); err != nil {
+			return err
+		}
+		return errors.New("plugin failed")
+	})
+	assert.EqualError(t, err, "plugin failed")
+
+	routes := router.Routes()
+	assert.Len(t, routes, 1)
+	assert.Equal(t, "/a", routes[0].Path)
+}
+
+func TestCopyOnWriteRouter_InFlightRequestKeepsSnapshot(t *testing.T) {
+	e := NewWithConfig(Config{Router: NewCopyOnWriteRouter(RouterConfig{})})
+
+	routed := make(chan struct{})
+	release := make(chan struct{})
+	e.GET("/slow/:id", func(c *Context) error {
+		close(routed)
+		<-release
+		return c.String(http.StatusOK, c.Param("id")+" "+c.RouteInfo().Path)
+	})
+
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow/1", nil))
+	}()
+	<-routed
+
+	// route is removed while request is being handled
+	assert.NoError(t, e.Router().Remove(http.MethodGet, "/slow/:id"))
+	close(release)
+	<-done
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1 /slow/:id", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow/1", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCopyOnWriteRouter_ConcurrentReadWrite(t *testing.T) {
+	router := NewCopyOnWriteRouter(RouterConfig{})
+	_, err := router.Add(Route{Method: http.MethodGet, Path: "/static/:id", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Go(func() {
+			for j := range 20 {
+				path := fmt.Sprintf("/dynamic/%d/%d/:p1/:p2", i, j)
+				_, err := router.Add(Route{Method: http.MethodGet, Path: path, Handler: handlerFunc})
+				assert.NoError(t, err)
+				assert.NoError(t, router.Remove(http.MethodGet, path))
+			}
+		})
+	}
+	for range 5 {
+		wg.Go(func() {
+			for range 100 {
+				req := httptest.NewRequest(http.MethodGet, "/static/1", nil)
+				c := newContext(req, httptest.NewRecorder(), nil)
+				_ = router.Route(c)(c)
+				assert.Equal(t, "1", c.Param("id"))
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Len(t, router.Routes(), 1)
+}
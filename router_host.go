@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// hostRouters holds routers for host specific routes of the DefaultRouter.
+type hostRouters struct {
+	// exact contains routers for hosts without placeholders by normalized host
+	exact map[string]*DefaultRouter
+	// patterns contains routers for hosts with placeholders in order they were added
+	patterns []*DefaultRouter
+	// all contains all host routers in order they were added
+	all []*DefaultRouter
+}
+
+// hostPattern is parsed Route.Host value.
+type hostPattern struct {
+	// regex is nil for hosts without placeholders
+	regex   *regexp.Regexp
+	pattern string
+	params  []string
+}
+
+// parseHostPattern parses host pattern like `{tenant}.example.com`. Placeholder matches characters until next dot.
+func parseHostPattern(host string) (*hostPattern, error) {
+	pattern := normalizeHost(host)
+	if pattern == "" {
+		return nil, errors.New("empty host pattern")
+	}
+	hp := &hostPattern{pattern: pattern}
+	if !strings.ContainsAny(pattern, "{}") {
+		return hp, nil
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	rest := pattern
+	for rest != "" {
+		start := strings.IndexByte(rest, '{')
+		if start == -1 {
+			if strings.IndexByte(rest, '}') != -1 {
+				return nil, errors.New("invalid host pattern, unexpected '}'")
+			}
+			expr.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end == -1 {
+			return nil, errors.New("invalid host pattern, missing '}'")
+		}
+		end += start
+		name := rest[start+1 : end]
+		if name == "" || strings.ContainsAny(name, "{.") || strings.IndexByte(rest[:start], '}') != -1 {
+			return nil, errors.New("invalid host pattern placeholder")
+		}
+		expr.WriteString(regexp.QuoteMeta(rest[:start]))
+		expr.WriteString("([^.]+)")
+		hp.params = append(hp.params, name)
+		rest = rest[end+1:]
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, err
+	}
+	hp.regex = re
+	return hp, nil
+}
+
+// normalizeHost removes port and trailing dot from the host and lowercases it.
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	} else if len(host) > 1 && host[0] == '[' && host[len(host)-1] == ']' {
+		host = host[1 : len(host)-1] // IPv6 address without port
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// hostRouter returns router for given host pattern. Router is created when it does not exist.
+func (r *DefaultRouter) hostRouter(host string) (*DefaultRouter, error) {
+	hp, err := parseHostPattern(host)
+	if err != nil {
+		return nil, err
+	}
+	if r.hosts == nil {
+		r.hosts = &hostRouters{exact: make(map[string]*DefaultRouter)}
+	}
+	for _, hr := range r.hosts.all {
+		if hr.host.pattern == hp.pattern {
+			return hr, nil
+		}
+	}
+
+	hr := &DefaultRouter{
+		tree: &node{
+			methods:   new(routeMethods),
+			isLeaf:    true,
+			isHandler: false,
+		},
+		routes: make(Routes, 0),
+
+		allowOverwritingRoute:    r.allowOverwritingRoute,
+		unescapePathParamValues:  r.unescapePathParamValues,
+		useEscapedPathForRouting: r.useEscapedPathForRouting,
+
+		notFoundHandler:         r.notFoundHandler,
+		methodNotAllowedHandler: r.methodNotAllowedHandler,
+		optionsMethodHandler:    r.optionsMethodHandler,
+		autoHandleHEAD:          r.autoHandleHEAD,
+
+		host: hp,
+	}
+	if hp.regex == nil {
+		r.hosts.exact[hp.pattern] = hr
+	} else {
+		r.hosts.patterns = append(r.hosts.patterns, hr)
+	}
+	r.hosts.all = append(r.hosts.all, hr)
+	return hr, nil
+}
+
+// match returns router and host parameter values for the request host. Hosts without placeholders have priority over
+// host patterns.
+func (h *hostRouters) match(host string) (*DefaultRouter, PathValues) {
+	host = normalizeHost(host)
+	if hr, ok := h.exact[host]; ok {
+		return hr, nil
+	}
+	for _, hr := range h.patterns {
+		m := hr.host.regex.FindStringSubmatch(host)
+		if m == nil {
+			continue
+		}
+		values := make(PathValues, len(hr.host.params))
+		for i, name := range hr.host.params {
+			values[i] = PathValue{Name: name, Value: m[i+1]}
+		}
+		return hr, values
+	}
+	return nil, nil
+}
+
+// withHostParams returns path parameter names appended with host parameter names.
+func (r *DefaultRouter) withHostParams(paramNames []string) []string {
+	if r.host == nil || len(r.host.params) == 0 {
+		return paramNames
+	}
+	return append(paramNames[:len(paramNames):len(paramNames)], r.host.params...)
+}
+
+// routeHost routes request with host router matching the request host. When host router has no route for the request
+// path, routes without host are tried before falling back to host router 404/405 handling.
+func (r *DefaultRouter) routeHost(c *Context) HandlerFunc {
+	hr, hostValues := r.hosts.match(c.Request().Host)
+	if hr == nil {
+		return r.route(c, nil)
+	}
+	h := hr.route(c, hostValues)
+	if isMatchedRoute(c.route) {
+		return h
+	}
+	if c.route == notFoundRouteInfo {
+		return r.route(c, nil) // host has no fallback (RouteNotFound route or 405) for the path
+	}
+
+	// host router result is stored because routing with other router overwrites context path values
+	hostResult := routeResult{
+		handler:    h,
+		route:      c.route,
+		group:      c.group,
+		path:       c.path,
+		pathValues: append(PathValues(nil), *c.pathValues...),
+		allow:      c.Get(ContextKeyHeaderAllow),
+	}
+	if h := r.route(c, nil); isMatchedRoute(c.route) {
+		return h
+	}
+	return hostResult.restore(c)
+}
+
+// routeResult is state of the context set by router for the request.
+type routeResult struct {
+	handler    HandlerFunc
+	route      *RouteInfo
+	group      *Group
+	path       string
+	pathValues PathValues
+	allow      any
+}
+
+// restore sets stored routing state back to the context and returns routed handler.
+func (rr routeResult) restore(c *Context) HandlerFunc {
+	c.InitializeRoute(rr.route, &rr.pathValues)
+	c.group = rr.group
+	c.SetPath(rr.path)
+	c.request.Pattern = rr.path
+	c.Set(ContextKeyHeaderAllow, rr.allow)
+	return rr.handler
+}
+
+// isMatchedRoute checks if router found actual route (not 404/405 or RouteNotFound route) for the request.
+func isMatchedRoute(ri *RouteInfo) bool {
+	return ri != notFoundRouteInfo && ri != methodNotAllowedRouteInfo && ri.Method != RouteNotFound
+}
@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEcho_Host(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		whenHost         string
+		whenMethod       string
+		whenURL          string
+		expectCode       int
+		expectBody       string
+		expectPathValues PathValues
+	}{
+		{
+			name:             "ok, exact host",
+			whenHost:         "api.example.com",
+			whenURL:          "/users",
+			expectCode:       http.StatusOK,
+			expectBody:       "api users",
+			expectPathValues: PathValues{},
+		},
+		{
+			name:             "ok, exact host has priority over pattern",
+			whenHost:         "admin.example.com",
+			whenURL:          "/users",
+			expectCode:       http.StatusOK,
+			expectBody:       "admin users",
+			expectPathValues: PathValues{},
+		},
+		{
+			name:             "ok, host pattern with placeholder",
+			whenHost:         "acme.example.com",
+			whenURL:          "/users/1",
+			expectCode:       http.StatusOK,
+			expectBody:       "tenant user",
+			expectPathValues: PathValues{{Name: "id", Value: "1"}, {Name: "tenant", Value: "acme"}},
+		},
+		{
+			name:             "ok, host matching ignores port and case",
+			whenHost:         "ACME.Example.com:8080",
+			whenURL:          "/users/1",
+			expectCode:       http.StatusOK,
+			expectBody:       "tenant user",
+			expectPathValues: PathValues{{Name: "id", Value: "1"}, {Name: "tenant", Value: "acme"}},
+		},
+		{
+			name:             "ok, placeholder does not match multiple host parts",
+			whenHost:         "a.b.example.com",
+			whenURL:          "/users",
+			expectCode:       http.StatusOK,
+			expectBody:       "users",
+			expectPathValues: PathValues{},
+		},
+		{
+			name:             "ok, unknown host uses routes without host",
+			whenHost:         "example.org",
+			whenURL:          "/users",
+			expectCode:       http.StatusOK,
+			expectBody:       "users",
+			expectPathValues: PathValues{},
+		},
+		{
+			name:             "ok, host without matching path falls back to routes without host",
+			whenHost:         "acme.example.com",
+			whenURL:          "/health",
+			expectCode:       http.StatusOK,
+			expectBody:       "ok",
+			expectPathValues: PathValues{},
+		},
+		{
+			name:             "nok, host path with other method is 405",
+			whenHost:         "acme.example.com",
+			whenMethod:       http.MethodPost,
+			whenURL:          "/users/1",
+			expectCode:       http.StatusMethodNotAllowed,
+			expectBody:       "{\"message\":\"Method Not Allowed\"}\n",
+			expectPathValues: PathValues{{}, {Name: "tenant", Value: "acme"}}, // path params are not named for 405
+		},
+		{
+			name:             "nok, not found",
+			whenHost:         "acme.example.com",
+			whenURL:          "/nope",
+			expectCode:       http.StatusNotFound,
+			expectBody:       "{\"message\":\"Not Found\"}\n",
+			expectPathValues: PathValues{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			var pathValues PathValues
+			handler := func(body string) HandlerFunc {
+				return func(c *Context) error {
+					pathValues = c.PathValues()
+					return c.String(http.StatusOK, body)
+				}
+			}
+			e.GET("/users", handler("users"))
+			e.GET("/health", handler("ok"))
+			e.Host("api.example.com").GET("/users", handler("api users"))
+			e.Host("admin.example.com").GET("/users", handler("admin users"))
+
+			tenant := e.Host("{tenant}.example.com")
+			tenant.GET("/users", handler("tenant users"))
+			tenant.GET("/users/:id", handler("tenant user"))
+
+			method := http.MethodGet
+			if tc.whenMethod != "" {
+				method = tc.whenMethod
+			}
+			req := httptest.NewRequest(method, tc.whenURL, nil)
+			req.Host = tc.whenHost
+			rec := httptest.NewRecorder()
+
+			// capture path values for error cases
+			e.Use(func(next HandlerFunc) HandlerFunc {
+				return func(c *Context) error {
+					pathValues = append(PathValues{}, c.PathValues()...)
+					return next(c)
+				}
+			})
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+			assert.Equal(t, tc.expectPathValues, pathValues)
+		})
+	}
+}
+
+func TestEcho_HostGroupMiddlewareAndRouteInfo(t *testing.T) {
+	e := New()
+	e.GET("/users", func(c *Context) error {
+		return c.String(http.StatusOK, "users")
+	})
+
+	var ri RouteInfo
+	api := e.Host("api.example.com:443", func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			c.Response().Header().Set("X-Host-Group", "api")
+			return next(c)
+		}
+	})
+	v1 := api.Group("/v1")
+	v1.GET("/users/:id", func(c *Context) error {
+		ri = c.RouteInfo()
+		return c.String(http.StatusOK, c.Param("id"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/5", nil)
+	req.Host = "api.example.com"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "5", rec.Body.String())
+	assert.Equal(t, "api", rec.Header().Get("X-Host-Group"))
+	assert.Equal(t, "api.example.com:443", ri.Host)
+	assert.Equal(t, "/v1/users/:id", ri.Path)
+
+	// group middleware is not executed for routes without host
+	req = httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Host = "api.example.com"
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "users", rec.Body.String())
+	assert.Equal(t, "", rec.Header().Get("X-Host-Group"))
+}
+
+func TestEcho_HostGroupRouteNotFound(t *testing.T) {
+	e := New()
+	e.GET("/users", func(c *Context) error {
+		return c.String(http.StatusOK, "users")
+	})
+	e.Host("{tenant}.example.com").RouteNotFound("/*", func(c *Context) error {
+		return c.String(http.StatusNotFound, "tenant "+c.Param("tenant")+" not found")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Host = "acme.example.com"
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "users", rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req.Host = "acme.example.com"
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "tenant acme not found", rec.Body.String())
+
+	// routes without host match path with other method. Host result is used without routing again.
+	e.GET("/:a/:b", func(c *Context) error {
+		return c.String(http.StatusOK, "a/b")
+	})
+	req = httptest.NewRequest(http.MethodPost, "/x/y", nil)
+	req.Host = "acme.example.com"
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "tenant acme not found", rec.Body.String())
+	assert.Equal(t, "", rec.Header().Get(HeaderAllow))
+}
+
+func TestDefaultRouter_routeHost_keepsHostResult(t *testing.T) {
+	r := NewRouter(RouterConfig{})
+	_, err := r.Add(Route{Method: http.MethodGet, Path: "/:a/:b", Handler: handlerFunc})
+	assert.NoError(t, err)
+	_, err = r.Add(Route{Method: RouteNotFound, Path: "/*", Host: "{tenant}.example.com", Handler: handlerFunc})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/x/y", nil)
+	req.Host = "acme.example.com"
+	c := NewContext(req, nil)
+	c.pathValues = &PathValues{{}, {}, {}}
+	r.Route(c)
+
+	assert.Equal(t, RouteNotFound, c.RouteInfo().Method)
+	assert.Equal(t, "/*", c.Path())
+	assert.Equal(t, "/*", req.Pattern)
+	assert.Equal(t, PathValues{{Name: "*", Value: "x/y"}, {Name: "tenant", Value: "acme"}}, c.PathValues())
+	assert.Nil(t, c.Get(ContextKeyHeaderAllow))
+}
+
+func TestDefaultRouter_HostRoutes(t *testing.T) {
+	r := NewRouter(RouterConfig{})
+
+	_, err := r.Add(Route{Method: http.MethodGet, Path: "/users", Handler: handlerFunc})
+	assert.NoError(t, err)
+	ri, err := r.Add(Route{Method: http.MethodGet, Path: "/users/:id", Host: "{tenant}.example.com", Handler: handlerFunc})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "tenant"}, ri.Parameters)
+	assert.Equal(t, "{tenant}.example.com", ri.Host)
+
+	// same method+path is allowed for different hosts
+	_, err = r.Add(Route{Method: http.MethodGet, Path: "/users", Host: "api.example.com", Handler: handlerFunc})
+	assert.NoError(t, err)
+	_, err = r.Add(Route{Method: http.MethodGet, Path: "/users", Host: "API.example.com", Handler: handlerFunc})
+	assert.EqualError(t, err, "GET /users: adding duplicate route (same method+path) is not allowed")
+
+	routes := r.Routes()
+	assert.Len(t, routes, 3)
+	assert.Equal(t, "", routes[0].Host)
+	assert.Equal(t, "{tenant}.example.com", routes[1].Host)
+	assert.Equal(t, "api.example.com", routes[2].Host)
+
+	assert.NoError(t, r.Remove(http.MethodGet, "/users/:id"))
+	assert.Len(t, r.Routes(), 2)
+}
+
+func TestParseHostPattern(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		when          string
+		expectPattern string
+		expectParams  []string
+		expectErr     string
+	}{
+		{name: "ok, exact", when: "Example.com:8080", expectPattern: "example.com"},
+		{name: "ok, placeholders", when: "{tenant}.{region}.example.com", expectPattern: "{tenant}.{region}.example.com", expectParams: []string{"tenant", "region"}},
+		{name: "ok, partial label", when: "api-{env}.example.com", expectPattern: "api-{env}.example.com", expectParams: []string{"env"}},
+		{name: "nok, empty", when: ":80", expectErr: "empty host pattern"},
+		{name: "nok, missing closing brace", when: "{tenant.example.com", expectErr: "invalid host pattern, missing '}'"},
+		{name: "nok, unexpected closing brace", when: "tenant}.example.com", expectErr: "invalid host pattern, unexpected '}'"},
+		{name: "nok, empty placeholder", when: "{}.example.com", expectErr: "invalid host pattern placeholder"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hp, err := parseHostPattern(tc.when)
+			if tc.expectErr != "" {
+				assert.EqualError(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectPattern, hp.pattern)
+			assert.Equal(t, tc.expectParams, hp.params)
+		})
+	}
+}
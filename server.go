@@ -7,6 +7,7 @@ import (
 	"cmp"
 	stdContext "context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -14,6 +15,8 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/exec"
+	"os/signal"
 	"sync"
 	"time"
 )
@@ -34,6 +37,22 @@ type StartConfig struct {
 
 	// CertFilesystem is filesystem is used to read `certFile` and `keyFile` when StartTLS method is called.
 	CertFilesystem fs.FS
+	// CertFiles are additional certificates StartTLS serves besides `certFile` and `keyFile`. Certificate for the
+	// connection is selected by server name (SNI) sent by the client.
+	CertFiles []CertificateFiles
+	// CertReloadInterval enables certificate hot reloading in StartTLS. When set, certificate and key files are checked
+	// for changes with given interval and changed certificates are reloaded without restarting the server (see
+	// CertificateReloader). Rotation events are logged with Echo.Logger. `certFile` and `keyFile` must be file paths.
+	CertReloadInterval time.Duration
+
+	// ClientCAs are certificate authorities used to verify client certificates (mutual TLS) in StartTLS.
+	ClientCAs *x509.CertPool
+	// ClientCAFiles are PEM encoded certificate authority files read from CertFilesystem and added to ClientCAs.
+	ClientCAFiles []string
+	// ClientAuth is client certificate verification mode used by StartTLS. When ClientCAs or ClientCAFiles is set,
+	// defaults to tls.RequireAndVerifyClientCert. Use tls.VerifyClientCertIfGiven when only part of the routes require
+	// client certificate. Identity of the client can be checked per route with middleware.ClientCert.
+	ClientAuth tls.ClientAuthType
 
 	// TLSConfig is used to configure TLS. If Listener is set, TLSConfig is not used to create the listener.
 	TLSConfig *tls.Config
@@ -45,7 +64,7 @@ type StartConfig struct {
 	ListenerNetwork string
 	// ListenerAddrFunc will be called after listener is created and started to listen for connections. This is useful in
 	// testing situations when server is started on random port `address = ":0"` in that case you can get actual port where
-	// listener is listening on.
+	// listener is listening on. When Listeners is set, ListenerAddrFunc is called for each listener in their order.
 	ListenerAddrFunc func(addr net.Addr)
 
 	// GracefulTimeout is timeout value (defaults to 10sec) graceful shutdown will wait for server to handle ongoing requests
@@ -57,7 +76,53 @@ type StartConfig struct {
 
 	// BeforeServeFunc is callback that is called just before server starts to serve HTTP request.
 	// Use this callback when you want to configure http.Server different timeouts/limits/etc
+	// When Listeners is set, BeforeServeFunc is called for the server of each listener.
 	BeforeServeFunc func(s *http.Server) error
+
+	// Listeners are listeners to serve on simultaneously, each with its own http.Server. When set, Address, TLSConfig,
+	// Listener and ListenerNetwork fields are not used. All servers are shut down together when the context given to
+	// Start is cancelled and share GracefulTimeout. When any of the listeners fails, the others are shut down and Start
+	// returns errors of all failed listeners joined.
+	//
+	// Example, HTTP redirects with HTTPS and unix socket for sidecars:
+	//
+	//	sc := echo.StartConfig{
+	//		Listeners: []echo.ListenerConfig{
+	//			{Address: ":80", Handler: redirectToHTTPS},
+	//			{Address: ":443", TLSConfig: tlsConfig},
+	//			{Address: "/run/app.sock", ListenerNetwork: "unix"},
+	//		},
+	//	}
+	//	if err := sc.Start(ctx, e); err != nil {
+	//		slog.Error("failed to start server", "error", err)
+	//	}
+	Listeners []ListenerConfig
+
+	// H2C enables serving cleartext HTTP/2 (h2c) with prior knowledge on listeners without TLS. HTTP/1.1 is served on
+	// the same listeners. HTTP/2 protocol settings are taken from http.Server.HTTP2 field that can be set in
+	// BeforeServeFunc. Use this when TLS is terminated before the server, i.e. by service mesh sidecar.
+	// Note: upgrading HTTP/1.1 connection with `Upgrade: h2c` header (deprecated by RFC 9113) is not supported, such
+	// requests are served with HTTP/1.1.
+	H2C bool
+
+	// RestartSignal enables graceful restart (i.e. after binary upgrade) without closing listening sockets. When the
+	// process receives the signal, new process is started with RestartCommand and listening sockets are passed to it
+	// with systemd socket activation protocol. After the new process is ready to serve requests, this process stops
+	// accepting connections and drains ongoing requests with graceful shutdown, after which Start returns. When the new
+	// process exits or is not ready within RestartTimeout, it is killed and this process continues serving.
+	// New process must create its listeners with InheritListeners and serve them with StartConfig.Start, that notifies
+	// the old process when it is ready. Connections arriving before it starts accepting are queued by the operating
+	// system.
+	// Note: process supervisor must allow PID of the main process to change. Not supported on Windows.
+	RestartSignal os.Signal
+	// RestartTimeout is how long graceful restart waits for the new process to become ready to serve requests.
+	// Optional. Default value 30 seconds.
+	RestartTimeout time.Duration
+	// RestartCommand creates command that starts the new process on graceful restart. Listening sockets and socket
+	// activation environment variables are added to the command.
+	// Optional. Default starts the executable of this process with the same arguments, environment and standard
+	// input/outputs.
+	RestartCommand func() (*exec.Cmd, error)
 }
 
 // Start starts given Handler with HTTP(s) server.
@@ -73,82 +138,245 @@ func (sc StartConfig) StartTLS(ctx stdContext.Context, h http.Handler, certFile,
 	if certFs == nil {
 		certFs = os.DirFS(".")
 	}
-	cert, err := filepathOrContent(certFile, certFs)
-	if err != nil {
-		return err
+	if sc.TLSConfig == nil {
+		sc.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			NextProtos: []string{"h2"},
+			//NextProtos: []string{"http/1.1"}, // Disallow "h2", allow http
+		}
+	} else {
+		sc.TLSConfig = sc.TLSConfig.Clone() // certificates and client authentication must not change caller's config
 	}
-	key, err := filepathOrContent(keyFile, certFs)
-	if err != nil {
+
+	if err := sc.configureClientAuth(sc.TLSConfig, certFs); err != nil {
 		return err
 	}
-	cer, err := tls.X509KeyPair(cert, key)
+
+	if sc.CertReloadInterval > 0 {
+		certPath, certOk := certFile.(string)
+		keyPath, keyOk := keyFile.(string)
+		if !certOk || !keyOk {
+			return fmt.Errorf("%w: certificate reloading requires file paths", ErrInvalidCertOrKeyType)
+		}
+		files := append([]CertificateFiles{{CertFile: certPath, KeyFile: keyPath}}, sc.CertFiles...)
+		reloader, err := NewCertificateReloader(certFs, serverLogger(h), files...)
+		if err != nil {
+			return err
+		}
+		sc.TLSConfig.Certificates = nil
+		sc.TLSConfig.GetCertificate = reloader.GetCertificate
+
+		wg := sync.WaitGroup{}
+		defer wg.Wait()
+		ctx, cancel := stdContext.WithCancel(ctx)
+		defer cancel() // stop watching when server stops
+		wg.Go(func() {
+			reloader.Watch(ctx, sc.CertReloadInterval)
+		})
+		return sc.start(ctx, h)
+	}
+
+	cer, err := loadCertificate(certFile, keyFile, certFs)
 	if err != nil {
 		return err
 	}
-	if sc.TLSConfig == nil {
-		sc.TLSConfig = &tls.Config{
-			MinVersion: tls.VersionTLS12,
-			NextProtos: []string{"h2"},
-			//NextProtos: []string{"http/1.1"}, // Disallow "h2", allow http
+	certificates := []tls.Certificate{cer}
+	for _, f := range sc.CertFiles {
+		if cer, err = loadCertificate(f.CertFile, f.KeyFile, certFs); err != nil {
+			return err
 		}
+		certificates = append(certificates, cer)
 	}
-	sc.TLSConfig.Certificates = []tls.Certificate{cer}
+	sc.TLSConfig.Certificates = certificates
 	return sc.start(ctx, h)
 }
 
-// start starts handler with HTTP(s) server.
-func (sc StartConfig) start(ctx stdContext.Context, h http.Handler) error {
-	var logger *slog.Logger
+// configureClientAuth configures client certificate verification (mutual TLS) of TLS config.
+func (sc StartConfig) configureClientAuth(tlsConfig *tls.Config, certFs fs.FS) error {
+	clientCAs := sc.ClientCAs
+	if len(sc.ClientCAFiles) > 0 {
+		if clientCAs == nil {
+			clientCAs = x509.NewCertPool()
+		} else {
+			clientCAs = clientCAs.Clone()
+		}
+		for _, file := range sc.ClientCAFiles {
+			pemCerts, err := fs.ReadFile(certFs, file)
+			if err != nil {
+				return err
+			}
+			if !clientCAs.AppendCertsFromPEM(pemCerts) {
+				return fmt.Errorf("client CA file %s does not contain PEM encoded certificates", file)
+			}
+		}
+	}
+	clientAuth := sc.ClientAuth
+	if clientCAs != nil {
+		tlsConfig.ClientCAs = clientCAs
+		if clientAuth == tls.NoClientCert {
+			clientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	if clientAuth != tls.NoClientCert {
+		tlsConfig.ClientAuth = clientAuth
+	}
+	return nil
+}
+
+func loadCertificate(certFile, keyFile any, certFs fs.FS) (tls.Certificate, error) {
+	cert, err := filepathOrContent(certFile, certFs)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	key, err := filepathOrContent(keyFile, certFs)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(cert, key)
+}
+
+// serverLogger returns logger of the Echo instance or new JSON logger when handler is not Echo.
+func serverLogger(h http.Handler) *slog.Logger {
 	if e, ok := h.(*Echo); ok {
-		logger = e.Logger
-	} else {
-		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		return e.Logger
 	}
+	return slog.New(slog.NewJSONHandler(os.Stdout, nil))
+}
+
+// ListenerConfig configures one of the listeners StartConfig serves on when StartConfig.Listeners is set.
+type ListenerConfig struct {
+	// Address specifies the address where listener will start listening on to serve HTTP(s) requests
+	Address string
+
+	// TLSConfig is used to configure TLS for this listener. If Listener is set, TLSConfig is not used to create the
+	// listener. Listener with nil TLSConfig serves plain HTTP.
+	TLSConfig *tls.Config
+
+	// Listener is used to serve on the custom listener.
+	Listener net.Listener
+	// ListenerNetwork is used configure on which Network listener will use (i.e. `unix` for unix domain socket).
+	// If Listener is set, ListenerNetwork is not used.
+	ListenerNetwork string
+
+	// Handler serves requests of this listener. Defaults to the handler given to StartConfig.Start method.
+	Handler http.Handler
 
-	server := http.Server{
-		Handler:  h,
-		ErrorLog: slog.NewLogLogger(logger.Handler(), slog.LevelError),
-		// defaults for GoSec rule G112 // https://github.com/securego/gosec
-		// G112 (CWE-400): Potential Slowloris Attack because ReadHeaderTimeout is not configured in the http.Server
-		ReadTimeout: 30 * time.Second,
-		// WriteTimeout is a max time allowed to write the response
-		// IMPORTANT: set this to 0 when using Server-Sent-Events (SSE) or some larger duration when serving static files
-		// WriteTimeout: 30 * time.Second,
+	// Name is the name of the listener passed to the new process on graceful restart (see StartConfig.RestartSignal).
+	// New process can get the listener by name with InheritedListeners.Named.
+	// Optional. Default value "unknown".
+	Name string
+}
+
+// listen creates listener. Listener is not wrapped with TLS, so it can be passed to the new process on restart.
+func (lc ListenerConfig) listen(ctx stdContext.Context) (net.Listener, error) {
+	if lc.Listener != nil {
+		return lc.Listener, nil
 	}
+	listenerNetwork := cmp.Or(lc.ListenerNetwork, "tcp")
 
-	listener := sc.Listener
-	if listener == nil {
-		listenerNetwork := cmp.Or(sc.ListenerNetwork, "tcp")
+	return (&net.ListenConfig{}).Listen(ctx, listenerNetwork, lc.Address)
+}
 
-		ln, err := (&net.ListenConfig{}).Listen(ctx, listenerNetwork, sc.Address)
-		if err != nil {
-			return err
-		}
-		listener = ln
+// start starts handler with HTTP(s) server for each listener.
+func (sc StartConfig) start(ctx stdContext.Context, h http.Handler) error {
+	logger := serverLogger(h)
 
-		if sc.TLSConfig != nil {
-			listener = tls.NewListener(listener, sc.TLSConfig)
-		}
+	listenerConfigs := sc.Listeners
+	if len(listenerConfigs) == 0 {
+		listenerConfigs = []ListenerConfig{{
+			Address:         sc.Address,
+			TLSConfig:       sc.TLSConfig,
+			Listener:        sc.Listener,
+			ListenerNetwork: sc.ListenerNetwork,
+		}}
 	}
 
-	if sc.ListenerAddrFunc != nil {
-		sc.ListenerAddrFunc(listener.Addr())
+	restartCh := make(chan os.Signal, 1)
+	if sc.RestartSignal != nil {
+		signal.Notify(restartCh, sc.RestartSignal)
+		defer signal.Stop(restartCh)
 	}
 
-	if sc.BeforeServeFunc != nil {
-		if err := sc.BeforeServeFunc(&server); err != nil {
-			_ = listener.Close()
+	shuttingDown := make(chan struct{})
+	onShutdown := sync.OnceFunc(func() {
+		close(shuttingDown)
+	})
+
+	listeners := make([]net.Listener, 0, len(listenerConfigs))
+	closeListeners := func() {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+	}
+	// rawListeners are listeners without TLS layer, that are passed to the new process on restart
+	rawListeners := make([]net.Listener, 0, len(listenerConfigs))
+	names := make([]string, 0, len(listenerConfigs))
+	servers := make([]*http.Server, 0, len(listenerConfigs))
+	for _, lc := range listenerConfigs {
+		listener, err := lc.listen(ctx)
+		if err != nil {
+			closeListeners()
 			return err
 		}
+		rawListeners = append(rawListeners, listener)
+		names = append(names, cmp.Or(lc.Name, unknownListenerName))
+		if lc.Listener == nil && lc.TLSConfig != nil {
+			listener = tls.NewListener(listener, lc.TLSConfig)
+		}
+		listeners = append(listeners, listener)
+
+		handler := lc.Handler
+		if handler == nil {
+			handler = h
+		}
+		server := &http.Server{
+			Handler:  handler,
+			ErrorLog: slog.NewLogLogger(logger.Handler(), slog.LevelError),
+			// BaseContext allows long-lived handlers (i.e. Context.SSE) to notice that graceful shutdown has started, as
+			// http.Server.Shutdown does not cancel request contexts and waits for active connections to become idle.
+			BaseContext: func(_ net.Listener) stdContext.Context {
+				return stdContext.WithValue(stdContext.Background(), serverShutdownKey{}, (<-chan struct{})(shuttingDown))
+			},
+			// defaults for GoSec rule G112 // https://github.com/securego/gosec
+			// G112 (CWE-400): Potential Slowloris Attack because ReadHeaderTimeout is not configured in the http.Server
+			ReadTimeout: 30 * time.Second,
+			// WriteTimeout is a max time allowed to write the response
+			// IMPORTANT: set this to 0 when using Server-Sent-Events (SSE) or some larger duration when serving static files
+			// WriteTimeout: 30 * time.Second,
+		}
+		if sc.H2C {
+			server.Protocols = new(http.Protocols)
+			server.Protocols.SetHTTP1(true)
+			server.Protocols.SetHTTP2(true)
+			server.Protocols.SetUnencryptedHTTP2(true)
+		}
+		server.RegisterOnShutdown(onShutdown)
+		servers = append(servers, server)
+
+		if sc.ListenerAddrFunc != nil {
+			sc.ListenerAddrFunc(listener.Addr())
+		}
+		if sc.BeforeServeFunc != nil {
+			if err := sc.BeforeServeFunc(server); err != nil {
+				closeListeners()
+				return err
+			}
+		}
 	}
+
 	if !sc.HideBanner {
 		bannerText := fmt.Sprintf(banner, Version)
 		logger.Info(bannerText, "version", Version)
 	}
 	if !sc.HidePort {
-		logger.Info("http(s) server started", "address", listener.Addr().String())
+		for _, listener := range listeners {
+			logger.Info("http(s) server started", "address", listener.Addr().String())
+		}
 	}
 
+	// servers are set up, process that started this process on graceful restart can stop accepting connections
+	notifyRestartReady()
+
 	wg := sync.WaitGroup{}
 	defer wg.Wait() // wait for graceful shutdown goroutine to finish
 
@@ -157,14 +385,64 @@ func (sc StartConfig) start(ctx stdContext.Context, h http.Handler) error {
 
 	if sc.GracefulTimeout >= 0 {
 		wg.Go(func() {
-			gracefulShutdown(gCtx, &sc, &server, logger)
+			gracefulShutdown(gCtx, &sc, servers, logger)
+		})
+	}
+	if sc.RestartSignal != nil {
+		wg.Go(func() {
+			for {
+				select {
+				case <-gCtx.Done():
+					return
+				case <-restartCh:
+				}
+				cmd, err := sc.restart(rawListeners, names)
+				if err != nil {
+					logger.Error("failed to restart server, continuing to serve", "error", err)
+					continue
+				}
+				logger.Info("server restarted, shutting down", "pid", cmd.Process.Pid)
+				// new process is ready and accepts connections from now on, stop accepting and drain ongoing requests
+				cancel()
+				if sc.GracefulTimeout < 0 {
+					for _, s := range servers {
+						_ = s.Close()
+					}
+				}
+				return
+			}
 		})
 	}
 
-	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return err
+	errs := make([]error, len(servers))
+	serveWg := sync.WaitGroup{}
+	for i, server := range servers {
+		serveWg.Go(func() {
+			if err := server.Serve(listeners[i]); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs[i] = err
+				// one listener failing stops the others
+				cancel()
+				if sc.GracefulTimeout < 0 {
+					for _, s := range servers {
+						_ = s.Close()
+					}
+				}
+			}
+		})
 	}
-	return nil
+	serveWg.Wait()
+	return errors.Join(errs...)
+}
+
+// serverShutdownKey is context key for channel that is closed when server started with StartConfig begins graceful
+// shutdown.
+type serverShutdownKey struct{}
+
+// serverShutdownSignal returns channel that is closed when server serving the request begins graceful shutdown. Returns
+// nil when server was not started with StartConfig.
+func serverShutdownSignal(ctx stdContext.Context) <-chan struct{} {
+	ch, _ := ctx.Value(serverShutdownKey{}).(<-chan struct{})
+	return ch
 }
 
 func filepathOrContent(fileOrContent any, certFilesystem fs.FS) (content []byte, err error) {
@@ -178,7 +456,7 @@ func filepathOrContent(fileOrContent any, certFilesystem fs.FS) (content []byte,
 	}
 }
 
-func gracefulShutdown(shutdownCtx stdContext.Context, sc *StartConfig, server *http.Server, logger *slog.Logger) {
+func gracefulShutdown(shutdownCtx stdContext.Context, sc *StartConfig, servers []*http.Server, logger *slog.Logger) {
 	<-shutdownCtx.Done() // wait until shutdown context is closed.
 	// note: is server if closed by other means this method is still run but is good as no-op
 
@@ -189,7 +467,17 @@ func gracefulShutdown(shutdownCtx stdContext.Context, sc *StartConfig, server *h
 	waitShutdownCtx, cancel := stdContext.WithTimeout(stdContext.Background(), timeout)
 	defer cancel()
 
-	if err := server.Shutdown(waitShutdownCtx); err != nil {
+	// all servers are shut down simultaneously and share the same timeout
+	errs := make([]error, len(servers))
+	wg := sync.WaitGroup{}
+	for i, server := range servers {
+		wg.Go(func() {
+			errs[i] = server.Shutdown(waitShutdownCtx)
+		})
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
 		// we end up here when listeners are not shut down within given timeout
 		if sc.OnShutdownError != nil {
 			sc.OnShutdownError(err)
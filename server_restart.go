@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables of systemd socket activation protocol. See
+// https://www.freedesktop.org/software/systemd/man/latest/sd_listen_fds.html
+const (
+	envListenPID     = "LISTEN_PID"
+	envListenFDs     = "LISTEN_FDS"
+	envListenFDNames = "LISTEN_FDNAMES"
+
+	// listenFDsStart is the first file descriptor passed with socket activation.
+	listenFDsStart = 3
+	// unknownListenerName is the name of the listener that was passed without name.
+	unknownListenerName = "unknown"
+
+	// envRestartReadyFD is the file descriptor of the pipe new process notifies through that it is ready to serve
+	// requests on graceful restart.
+	envRestartReadyFD = "ECHO_RESTART_READY_FD"
+	// defaultRestartTimeout is how long the old process waits for the new process to become ready.
+	defaultRestartTimeout = 30 * time.Second
+)
+
+// InheritedListeners are listening sockets the process has inherited with systemd socket activation or from the
+// previous process during graceful restart (see StartConfig.RestartSignal).
+type InheritedListeners struct {
+	listeners []net.Listener
+	names     []string
+}
+
+// InheritListeners creates listeners from file descriptors passed to the process with systemd socket activation
+// protocol (`LISTEN_FDS`, `LISTEN_FDNAMES` and `LISTEN_PID` environment variables). Returns empty InheritedListeners
+// when no listeners were passed to the process. Socket activation environment variables are unset, so they are not
+// passed on to child processes and InheritListeners should be called only once.
+// Not supported on Windows.
+//
+// Example:
+//
+//	inherited, err := echo.InheritListeners()
+//	if err != nil {
+//		return err
+//	}
+//	sc := echo.StartConfig{
+//		Address:       ":8080",
+//		Listener:      inherited.Index(0), // nil when process was started without listeners, Address is used then
+//		RestartSignal: syscall.SIGHUP,
+//	}
+//	return sc.Start(ctx, e)
+func InheritListeners() (*InheritedListeners, error) {
+	count := os.Getenv(envListenFDs)
+	if count == "" {
+		return &InheritedListeners{}, nil
+	}
+	// LISTEN_PID is not set by parent process during graceful restart as it does not know PID of the new process
+	if pid := os.Getenv(envListenPID); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return &InheritedListeners{}, nil // listeners were passed to some other process
+	}
+	names := os.Getenv(envListenFDNames)
+	_ = os.Unsetenv(envListenPID)
+	_ = os.Unsetenv(envListenFDs)
+	_ = os.Unsetenv(envListenFDNames)
+
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid %s value %q", envListenFDs, count)
+	}
+	result := &InheritedListeners{
+		listeners: make([]net.Listener, 0, n),
+		names:     make([]string, n),
+	}
+	if names != "" {
+		copy(result.names, strings.Split(names, ":"))
+	}
+	for i := range n {
+		name := cmp.Or(result.names[i], unknownListenerName)
+		result.names[i] = name
+
+		fd := listenFDsStart + i
+		f := os.NewFile(uintptr(fd), name)
+		l, err := net.FileListener(f) // duplicates the file descriptor
+		_ = f.Close()
+		if err != nil {
+			for _, l := range result.listeners {
+				_ = l.Close()
+			}
+			return nil, fmt.Errorf("inherited file descriptor %d (%s) is not a listening socket: %w", fd, name, err)
+		}
+		result.listeners = append(result.listeners, l)
+	}
+	return result, nil
+}
+
+// Len returns number of inherited listeners.
+func (il *InheritedListeners) Len() int {
+	return len(il.listeners)
+}
+
+// Index returns inherited listener by its index or nil when there is no such listener.
+func (il *InheritedListeners) Index(index int) net.Listener {
+	if index < 0 || index >= len(il.listeners) {
+		return nil
+	}
+	return il.listeners[index]
+}
+
+// Named returns the first inherited listener with given name (systemd `FileDescriptorName=` or ListenerConfig.Name)
+// or nil when there is no such listener.
+func (il *InheritedListeners) Named(name string) net.Listener {
+	if i := slices.Index(il.names, name); i != -1 {
+		return il.listeners[i]
+	}
+	return nil
+}
+
+// restart starts new process, passes listeners to it with socket activation protocol and waits until the new process
+// is ready to serve requests. When the new process exits or does not become ready within RestartTimeout, it is killed
+// and an error is returned, so this process can continue serving.
+func (sc StartConfig) restart(listeners []net.Listener, names []string) (*exec.Cmd, error) {
+	files := make([]*os.File, 0, len(listeners)+1)
+	defer func() {
+		// new process has its own copies of the file descriptors
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+	for _, l := range listeners {
+		fl, ok := l.(interface{ File() (*os.File, error) })
+		if !ok {
+			return nil, fmt.Errorf("listener %T can not be passed to new process", l)
+		}
+		f, err := fl.File()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer readyR.Close()
+	files = append(files, readyW) // write end is closed by deferred function, so only the new process holds it
+
+	var cmd *exec.Cmd
+	if sc.RestartCommand != nil {
+		var err error
+		if cmd, err = sc.RestartCommand(); err != nil {
+			return nil, err
+		}
+	} else {
+		executable, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		cmd = exec.Command(executable, os.Args[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+
+	env := cmd.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	env = slices.DeleteFunc(slices.Clone(env), func(v string) bool {
+		return strings.HasPrefix(v, envListenPID+"=") ||
+			strings.HasPrefix(v, envListenFDs+"=") ||
+			strings.HasPrefix(v, envListenFDNames+"=") ||
+			strings.HasPrefix(v, envRestartReadyFD+"=")
+	})
+	cmd.Env = append(env,
+		envListenFDs+"="+strconv.Itoa(len(listeners)),
+		envListenFDNames+"="+strings.Join(names, ":"),
+		envRestartReadyFD+"="+strconv.Itoa(listenFDsStart+len(listeners)),
+	)
+	// listeners must be the first extra files to get file descriptors starting from listenFDsStart
+	cmd.ExtraFiles = append(files, cmd.ExtraFiles...)
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	_ = readyW.Close()
+	files = files[:len(files)-1]
+	// starting the process puts passed file descriptors to blocking mode. Listener sockets share the mode with them, so
+	// blocked Accept would not return when this process closes its listeners. Creating listener from the file puts
+	// the socket back to non-blocking mode.
+	for _, f := range files {
+		if l, err := net.FileListener(f); err == nil {
+			_ = l.Close()
+		}
+	}
+	if err := waitRestartReady(readyR, cmp.Or(sc.RestartTimeout, defaultRestartTimeout)); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	for _, l := range listeners {
+		if ul, ok := l.(*net.UnixListener); ok {
+			ul.SetUnlinkOnClose(false) // socket file is used by the new process
+		}
+	}
+	return cmd, nil
+}
+
+// waitRestartReady waits until the new process writes to the ready pipe. Pipe is closed without writing when the new
+// process exits.
+func waitRestartReady(ready *os.File, timeout time.Duration) error {
+	if err := ready.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	_, err := ready.Read(make([]byte, 1))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, io.EOF):
+		return errors.New("new process exited before it was ready to serve requests")
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		return fmt.Errorf("new process did not become ready to serve requests within %v", timeout)
+	default:
+		return err
+	}
+}
+
+// notifyRestartReady notifies the old process that started this process on graceful restart that this process is ready
+// to serve requests, after which the old process stops accepting connections. Does nothing when the process was not
+// started on graceful restart.
+func notifyRestartReady() {
+	fd := os.Getenv(envRestartReadyFD)
+	if fd == "" {
+		return
+	}
+	_ = os.Unsetenv(envRestartReadyFD)
+	n, err := strconv.Atoi(fd)
+	if err != nil {
+		return
+	}
+	f := os.NewFile(uintptr(n), "restart-ready")
+	_, _ = f.Write([]byte{1})
+	_ = f.Close()
+}
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	stdContext "context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritListeners_notActivated(t *testing.T) {
+	t.Setenv(envListenFDs, "")
+
+	inherited, err := InheritListeners()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, inherited.Len())
+	assert.Nil(t, inherited.Index(0))
+	assert.Nil(t, inherited.Named("http"))
+}
+
+func TestInheritListeners_otherProcess(t *testing.T) {
+	t.Setenv(envListenFDs, "1")
+	t.Setenv(envListenPID, strconv.Itoa(os.Getpid()+1))
+
+	inherited, err := InheritListeners()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, inherited.Len())
+}
+
+func TestInheritListeners_invalidCount(t *testing.T) {
+	t.Setenv(envListenFDs, "x")
+	t.Setenv(envListenPID, strconv.Itoa(os.Getpid()))
+
+	_, err := InheritListeners()
+	assert.EqualError(t, err, `invalid LISTEN_FDS value "x"`)
+	assert.Empty(t, os.Getenv(envListenFDs))
+	assert.Empty(t, os.Getenv(envListenPID))
+}
+
+// TestStartConfig_restartHelperProcess is not a real test. It is run as the new process by TestStartConfig_Restart.
+func TestStartConfig_restartHelperProcess(t *testing.T) {
+	if os.Getenv("ECHO_TEST_RESTART_HELPER") != "1" {
+		return
+	}
+	inherited, err := InheritListeners()
+	require.NoError(t, err)
+	listener := inherited.Named("public")
+	require.NotNil(t, listener)
+
+	// shut down after serving one request
+	ctx, cancel := stdContext.WithTimeout(stdContext.Background(), 5*time.Second)
+	defer cancel()
+	e := New()
+	e.GET("/", func(c *Context) error {
+		defer cancel()
+		return c.String(http.StatusOK, "child")
+	})
+	err = (&StartConfig{Listener: listener, HideBanner: true, HidePort: true}).Start(ctx, e)
+	require.NoError(t, err)
+}
+
+func TestStartConfig_Restart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("passing listeners to new process is not supported on Windows")
+	}
+	e := New()
+	e.GET("/", func(c *Context) error {
+		return c.String(http.StatusOK, "parent")
+	})
+
+	var child *exec.Cmd
+	addrChan := make(chan string)
+	errCh := make(chan error)
+	go func() {
+		errCh <- (&StartConfig{
+			HideBanner:      true,
+			HidePort:        true,
+			GracefulTimeout: time.Second,
+			Listeners:       []ListenerConfig{{Address: "127.0.0.1:0", Name: "public"}},
+			RestartSignal:   syscall.SIGHUP,
+			RestartCommand: func() (*exec.Cmd, error) {
+				child = exec.Command(os.Args[0], "-test.run=^TestStartConfig_restartHelperProcess$")
+				child.Env = append(os.Environ(), "ECHO_TEST_RESTART_HELPER=1")
+				return child, nil
+			},
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+		}).Start(stdContext.Background(), e)
+	}()
+	addr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+	url := fmt.Sprintf("http://%v/", addr)
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	get := func() string {
+		res, err := client.Get(url)
+		if err != nil {
+			return err.Error()
+		}
+		defer res.Body.Close()
+		body := make([]byte, 16)
+		n, _ := res.Body.Read(body)
+		return string(body[:n])
+	}
+	assert.Equal(t, "parent", get())
+
+	p, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, p.Signal(syscall.SIGHUP))
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not shut down after restart")
+	}
+	// listening socket stays open and is served by the new process
+	assert.Equal(t, "child", get())
+
+	require.NotNil(t, child)
+	assert.NoError(t, child.Wait())
+}
+
+type logLines chan string
+
+func (l logLines) Write(p []byte) (int, error) {
+	l <- string(p)
+	return len(p), nil
+}
+
+func TestStartConfig_RestartNewProcessExits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("passing listeners to new process is not supported on Windows")
+	}
+	logs := make(logLines, 10)
+	e := New()
+	e.Logger = slog.New(slog.NewTextHandler(logs, nil))
+	e.GET("/", func(c *Context) error {
+		return c.String(http.StatusOK, "parent")
+	})
+
+	ctx, cancel := stdContext.WithCancel(stdContext.Background())
+	defer cancel()
+	addrChan := make(chan string)
+	errCh := make(chan error)
+	go func() {
+		errCh <- (&StartConfig{
+			HideBanner:      true,
+			HidePort:        true,
+			GracefulTimeout: time.Second,
+			Listeners:       []ListenerConfig{{Address: "127.0.0.1:0", Name: "public"}},
+			RestartSignal:   syscall.SIGHUP,
+			RestartCommand: func() (*exec.Cmd, error) {
+				// runs no tests and exits without notifying that it is ready
+				return exec.Command(os.Args[0], "-test.run=^$"), nil
+			},
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+		}).Start(ctx, e)
+	}()
+	addr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+
+	p, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, p.Signal(syscall.SIGHUP))
+
+	select {
+	case line := <-logs:
+		assert.Contains(t, line, "failed to restart server, continuing to serve")
+		assert.Contains(t, line, "new process exited before it was ready to serve requests")
+	case err := <-errCh:
+		t.Fatalf("server stopped after failed restart: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("restart did not fail")
+	}
+
+	// server keeps serving
+	res, err := http.Get(fmt.Sprintf("http://%v/", addr))
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "parent", string(body))
+
+	cancel()
+	assert.NoError(t, <-errCh)
+}
+
+func TestWaitRestartReady_timeout(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	err = waitRestartReady(r, 10*time.Millisecond)
+	assert.EqualError(t, err, "new process did not become ready to serve requests within 10ms")
+}
@@ -4,6 +4,7 @@
 package echo
 
 import (
+	"bufio"
 	"bytes"
 	stdContext "context"
 	"crypto/tls"
@@ -23,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
 )
 
 func startOnRandomPort(ctx stdContext.Context, e *Echo) (string, error) {
@@ -700,3 +702,315 @@ func TestStartConfig_WithHTTP2WithCustomTlsConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestStartConfig_Start_listeners(t *testing.T) {
+	e := New()
+	e.GET("/ok", func(c *Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+	health := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("healthy"))
+	})
+	cert, err := tls.LoadX509KeyPair("_fixture/certs/cert.pem", "_fixture/certs/key.pem")
+	require.NoError(t, err)
+
+	addrChan := make(chan string, 3)
+	errCh := make(chan error)
+
+	ctx, shutdown := stdContext.WithTimeout(stdContext.Background(), 500*time.Millisecond)
+	defer shutdown()
+	go func() {
+		errCh <- (&StartConfig{
+			HideBanner:      true,
+			GracefulTimeout: 100 * time.Millisecond,
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+			Listeners: []ListenerConfig{
+				{Address: "127.0.0.1:0"},
+				{Address: "127.0.0.1:0", TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}}},
+				{Address: "127.0.0.1:0", Handler: health},
+			},
+		}).Start(ctx, e)
+	}()
+
+	httpAddr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+	httpsAddr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+	healthAddr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+
+	code, body, err := doGet(fmt.Sprintf("http://%v/ok", httpAddr))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "OK", body)
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+	res, err := client.Get(fmt.Sprintf("https://%v/ok", httpsAddr))
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		_ = res.Body.Close()
+	}
+
+	code, body, err = doGet(fmt.Sprintf("http://%v/ok", healthAddr))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "healthy", body)
+
+	shutdown()
+	assert.NoError(t, <-errCh)
+
+	for _, addr := range []string{httpAddr, httpsAddr, healthAddr} {
+		_, _, err = doGet(fmt.Sprintf("http://%v/ok", addr))
+		assert.Error(t, err)
+	}
+}
+
+type failingListener struct {
+	net.Listener
+	err error
+}
+
+func (l *failingListener) Accept() (net.Conn, error) {
+	return nil, l.err
+}
+
+func TestStartConfig_Start_listenerFails(t *testing.T) {
+	e := New()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	shutdownCalled := make(chan struct{})
+	err = (&StartConfig{
+		HideBanner: true,
+		HidePort:   true,
+		Listeners: []ListenerConfig{
+			{Address: "127.0.0.1:0"},
+			{Listener: &failingListener{Listener: ln, err: errors.New("accept failed")}},
+		},
+		BeforeServeFunc: func(s *http.Server) error {
+			s.RegisterOnShutdown(func() {
+				select {
+				case <-shutdownCalled:
+				default:
+					close(shutdownCalled)
+				}
+			})
+			return nil
+		},
+	}).Start(stdContext.Background(), e)
+
+	assert.EqualError(t, err, "accept failed")
+	select {
+	case <-shutdownCalled:
+	default:
+		t.Error("other listeners were not shut down")
+	}
+}
+
+func TestStartConfig_Start_listenersCreateError(t *testing.T) {
+	e := New()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	err = (&StartConfig{
+		Listeners: []ListenerConfig{
+			{Listener: ln},
+			{Address: ":0", ListenerNetwork: "unknown"},
+		},
+	}).Start(stdContext.Background(), e)
+	assert.EqualError(t, err, "listen unknown: unknown network unknown")
+
+	_, err = ln.Accept()
+	assert.ErrorIs(t, err, net.ErrClosed)
+}
+
+func startH2COnRandomPort(t *testing.T, ctx stdContext.Context, e *Echo) (string, <-chan error) {
+	addrChan := make(chan string)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- (&StartConfig{
+			Address:         "127.0.0.1:0",
+			HideBanner:      true,
+			HidePort:        true,
+			H2C:             true,
+			GracefulTimeout: 2 * time.Second, // http.Server closes HTTP/2 connection 1 second after sending GOAWAY
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+		}).Start(ctx, e)
+	}()
+	addr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+	return addr, errCh
+}
+
+func TestStartConfig_H2C_priorKnowledge(t *testing.T) {
+	e := New()
+	e.GET("/proto", func(c *Context) error {
+		return c.String(http.StatusOK, c.Request().Proto)
+	})
+	flushed := make(chan struct{})
+	e.GET("/stream", func(c *Context) error {
+		c.Response().Header().Set(HeaderContentType, MIMETextPlain)
+		c.Response().WriteHeader(http.StatusOK)
+		_, _ = c.Response().Write([]byte(c.Request().Proto + "\n"))
+		res, err := UnwrapResponse(c.Response())
+		if err != nil {
+			return err
+		}
+		res.Flush()
+		<-flushed
+		_, err = c.Response().Write([]byte("done"))
+		return err
+	})
+
+	ctx, shutdown := stdContext.WithTimeout(stdContext.Background(), time.Second)
+	defer shutdown()
+	addr, _ := startH2COnRandomPort(t, ctx, e)
+
+	transport := &http.Transport{Protocols: new(http.Protocols)}
+	transport.Protocols.SetUnencryptedHTTP2(true)
+	res, err := (&http.Client{Transport: transport}).Get(fmt.Sprintf("http://%v/stream", addr))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, "HTTP/2.0", res.Proto)
+
+	// first line must arrive before handler continues
+	line := make([]byte, len("HTTP/2.0\n"))
+	_, err = io.ReadFull(res.Body, line)
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0\n", string(line))
+	close(flushed)
+
+	rest, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "done", string(rest))
+
+	// HTTP/1.1 is still served
+	code, body, err := doGet(fmt.Sprintf("http://%v/proto", addr))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "HTTP/1.1", body)
+}
+
+func TestStartConfig_H2C_upgradeIsServedWithHTTP1(t *testing.T) {
+	e := New()
+	e.GET("/proto", func(c *Context) error {
+		return c.String(http.StatusOK, c.Request().Proto)
+	})
+
+	ctx, shutdown := stdContext.WithTimeout(stdContext.Background(), time.Second)
+	defer shutdown()
+	addr, _ := startH2COnRandomPort(t, ctx, e)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetDeadline(time.Now().Add(time.Second)))
+
+	_, err = io.WriteString(conn, "GET /proto HTTP/1.1\r\n"+
+		"Host: "+addr+"\r\n"+
+		"Connection: Upgrade, HTTP2-Settings\r\n"+
+		"Upgrade: h2c\r\n"+
+		"HTTP2-Settings: AAMAAABkAAQAoAAAAAIAAAAA\r\n\r\n")
+	require.NoError(t, err)
+
+	res, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1", string(body))
+}
+
+func TestStartConfig_H2C_connectionIsShutDown(t *testing.T) {
+	e := New()
+	e.GET("/wait", func(c *Context) error {
+		// long-running handler on HTTP/2 connection is notified about graceful shutdown
+		<-serverShutdownSignal(c.Request().Context())
+		return c.String(http.StatusOK, "shutting down")
+	})
+
+	ctx, shutdown := stdContext.WithCancel(stdContext.Background())
+	defer shutdown()
+	addr, errCh := startH2COnRandomPort(t, ctx, e)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetDeadline(time.Now().Add(3*time.Second)))
+
+	_, err = io.WriteString(conn, http2.ClientPreface)
+	require.NoError(t, err)
+	framer := http2.NewFramer(conn, conn)
+	require.NoError(t, framer.WriteSettings())
+	headers := bytes.Buffer{}
+	encoder := hpack.NewEncoder(&headers)
+	for _, f := range []hpack.HeaderField{
+		{Name: ":method", Value: http.MethodGet},
+		{Name: ":scheme", Value: "http"},
+		{Name: ":authority", Value: addr},
+		{Name: ":path", Value: "/wait"},
+	} {
+		require.NoError(t, encoder.WriteField(f))
+	}
+	require.NoError(t, framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      1,
+		BlockFragment: headers.Bytes(),
+		EndStream:     true,
+		EndHeaders:    true,
+	}))
+	// wait until server has processed client SETTINGS and so is serving the connection
+	for {
+		frame, err := framer.ReadFrame()
+		require.NoError(t, err)
+		if settings, ok := frame.(*http2.SettingsFrame); ok && settings.IsAck() {
+			break
+		}
+	}
+	shutdown()
+
+	// server sends GOAWAY, finishes the stream and closes the connection
+	status := ""
+	body := ""
+	decoder := hpack.NewDecoder(4096, func(f hpack.HeaderField) {
+		if f.Name == ":status" {
+			status = f.Value
+		}
+	})
+	gotGoAway := false
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+			break
+		}
+		switch f := frame.(type) {
+		case *http2.GoAwayFrame:
+			gotGoAway = true
+			assert.Equal(t, http2.ErrCodeNo, f.ErrCode)
+		case *http2.HeadersFrame:
+			_, err := decoder.Write(f.HeaderBlockFragment())
+			require.NoError(t, err)
+		case *http2.DataFrame:
+			body += string(f.Data())
+		}
+	}
+	assert.True(t, gotGoAway)
+	assert.Equal(t, "200", status)
+	assert.Equal(t, "shutting down", body)
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	stdContext "context"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CertificateFiles is pair of PEM encoded certificate (chain) and private key file paths.
+type CertificateFiles struct {
+	CertFile string
+	KeyFile  string
+}
+
+// CertificateReloader serves TLS certificates through tls.Config.GetCertificate and reloads them when certificate or
+// key files change, so short-lived certificates can be rotated without restarting the server. New certificate is
+// validated before it replaces the old one, the old certificate is kept in use when the new one is invalid.
+//
+// Example:
+//
+//	reloader, err := echo.NewCertificateReloader(os.DirFS("/etc/certs"), e.Logger,
+//		echo.CertificateFiles{CertFile: "example.com.crt", KeyFile: "example.com.key"},
+//		echo.CertificateFiles{CertFile: "example.org.crt", KeyFile: "example.org.key"},
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	go reloader.Watch(ctx, 1*time.Minute)
+//
+//	sc := echo.StartConfig{
+//		Address:   ":443",
+//		TLSConfig: &tls.Config{GetCertificate: reloader.GetCertificate},
+//	}
+//	return sc.Start(ctx, e)
+type CertificateReloader struct {
+	filesystem fs.FS
+	logger     *slog.Logger
+	files      []CertificateFiles
+
+	mu sync.Mutex // serializes reloads
+	// state holds loaded certificates and versions of their files, in the same order as files
+	state atomic.Pointer[[]loadedCertificate]
+}
+
+type loadedCertificate struct {
+	certificate *tls.Certificate
+	version     certificateVersion
+}
+
+// certificateVersion identifies contents of certificate and key files.
+type certificateVersion struct {
+	certModTime time.Time
+	certSize    int64
+	keyModTime  time.Time
+	keySize     int64
+	hash        [sha256.Size]byte
+}
+
+// NewCertificateReloader creates CertificateReloader and loads certificates from given files. Certificate and key files
+// are read from filesystem. Returns error when any of the certificates can not be loaded.
+func NewCertificateReloader(filesystem fs.FS, logger *slog.Logger, files ...CertificateFiles) (*CertificateReloader, error) {
+	if len(files) == 0 {
+		return nil, errors.New("certificate reloader: at least one certificate is required")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	r := &CertificateReloader{
+		filesystem: filesystem,
+		logger:     logger,
+		files:      files,
+	}
+	loaded := make([]loadedCertificate, len(files))
+	for i, f := range files {
+		var err error
+		if loaded[i], err = r.reload(f, loadedCertificate{}); err != nil {
+			return nil, err
+		}
+	}
+	r.state.Store(&loaded)
+	return r, nil
+}
+
+// GetCertificate returns certificate for TLS handshake. Certificate is selected by server name (SNI) and
+// capabilities of the client. The first certificate is returned when none of the certificates matches.
+// GetCertificate is meant to be used as tls.Config.GetCertificate.
+func (r *CertificateReloader) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	loaded := *r.state.Load()
+	if len(loaded) > 1 {
+		for _, l := range loaded {
+			if hello.SupportsCertificate(l.certificate) == nil {
+				return l.certificate, nil
+			}
+		}
+	}
+	return loaded[0].certificate, nil
+}
+
+// Reload checks certificate and key files for changes and replaces certificates that have changed. Files are read only
+// when their modification time or size has changed, certificate is replaced only when file contents have changed.
+// Certificate that fails to load or validate is not replaced. Returns errors of all failed certificates joined.
+func (r *CertificateReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := *r.state.Load()
+	loaded := make([]loadedCertificate, len(current))
+	copy(loaded, current)
+
+	var errs []error
+	changed := false
+	for i, f := range r.files {
+		l, err := r.reload(f, current[i])
+		if err != nil {
+			errs = append(errs, err)
+			r.logger.Error("failed to reload TLS certificate", "cert_file", f.CertFile, "key_file", f.KeyFile, "error", err)
+			continue
+		}
+		if l == current[i] {
+			continue
+		}
+		loaded[i] = l
+		changed = true
+		if l.certificate == current[i].certificate {
+			continue // files were touched without changing their contents
+		}
+		r.logger.Info(
+			"TLS certificate reloaded",
+			"cert_file", f.CertFile,
+			"subject", l.certificate.Leaf.Subject.String(),
+			"not_after", l.certificate.Leaf.NotAfter,
+		)
+	}
+	if changed {
+		r.state.Store(&loaded)
+	}
+	return errors.Join(errs...)
+}
+
+// Watch calls Reload with given interval until context is cancelled.
+func (r *CertificateReloader) Watch(ctx stdContext.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = r.Reload() // errors are logged by Reload
+		}
+	}
+}
+
+// reload loads certificate when its files differ from the previously loaded certificate. Modification times and sizes
+// are compared first so files are not read when they have not changed. Certificate is parsed only when contents of
+// the files have changed.
+func (r *CertificateReloader) reload(f CertificateFiles, previous loadedCertificate) (loadedCertificate, error) {
+	certInfo, err := fs.Stat(r.filesystem, f.CertFile)
+	if err != nil {
+		return loadedCertificate{}, err
+	}
+	keyInfo, err := fs.Stat(r.filesystem, f.KeyFile)
+	if err != nil {
+		return loadedCertificate{}, err
+	}
+	version := certificateVersion{
+		certModTime: certInfo.ModTime(),
+		certSize:    certInfo.Size(),
+		keyModTime:  keyInfo.ModTime(),
+		keySize:     keyInfo.Size(),
+		hash:        previous.version.hash,
+	}
+	if previous.certificate != nil && version == previous.version {
+		return previous, nil
+	}
+
+	certPEM, err := fs.ReadFile(r.filesystem, f.CertFile)
+	if err != nil {
+		return loadedCertificate{}, err
+	}
+	keyPEM, err := fs.ReadFile(r.filesystem, f.KeyFile)
+	if err != nil {
+		return loadedCertificate{}, err
+	}
+	version.hash = sha256.Sum256(append(certPEM, keyPEM...))
+	if previous.certificate != nil && version.hash == previous.version.hash {
+		return loadedCertificate{certificate: previous.certificate, version: version}, nil
+	}
+
+	// X509KeyPair checks that private key matches the certificate and parses the leaf certificate
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return loadedCertificate{}, fmt.Errorf("invalid certificate %s: %w", f.CertFile, err)
+	}
+	if time.Now().After(cert.Leaf.NotAfter) {
+		return loadedCertificate{}, fmt.Errorf("invalid certificate %s: expired at %s", f.CertFile, cert.Leaf.NotAfter.Format(time.RFC3339))
+	}
+	return loadedCertificate{certificate: &cert, version: version}, nil
+}
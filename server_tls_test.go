@@ -0,0 +1,359 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"bytes"
+	stdContext "context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificate creates self-signed certificate for given DNS name and returns PEM encoded certificate and key.
+func newTestCertificate(t *testing.T, name string, notAfter time.Time) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeTestCertificate writes certificate files with given modification time.
+func writeTestCertificate(t *testing.T, dir string, name string, certPEM []byte, keyPEM []byte, modTime time.Time) {
+	for file, content := range map[string][]byte{name + ".crt": certPEM, name + ".key": keyPEM} {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.WriteFile(path, content, 0o600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestCertificateReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	modTime := time.Now().Add(-1 * time.Hour)
+	certA, keyA := newTestCertificate(t, "a.example.com", time.Now().Add(time.Hour))
+	writeTestCertificate(t, dir, "server", certA, keyA, modTime)
+
+	logs := new(syncBuffer)
+	reloader, err := NewCertificateReloader(os.DirFS(dir), slog.New(slog.NewTextHandler(logs, nil)),
+		CertificateFiles{CertFile: "server.crt", KeyFile: "server.key"},
+	)
+	require.NoError(t, err)
+
+	cert, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "a.example.com", cert.Leaf.Subject.CommonName)
+
+	// files touched without changing contents
+	modTime = modTime.Add(time.Minute)
+	writeTestCertificate(t, dir, "server", certA, keyA, modTime)
+	assert.NoError(t, reloader.Reload())
+	sameCert, _ := reloader.GetCertificate(&tls.ClientHelloInfo{})
+	assert.Same(t, cert, sameCert)
+	assert.Empty(t, logs.String())
+
+	// rotated certificate
+	certB, keyB := newTestCertificate(t, "b.example.com", time.Now().Add(time.Hour))
+	modTime = modTime.Add(time.Minute)
+	writeTestCertificate(t, dir, "server", certB, keyB, modTime)
+	assert.NoError(t, reloader.Reload())
+	cert, _ = reloader.GetCertificate(&tls.ClientHelloInfo{})
+	assert.Equal(t, "b.example.com", cert.Leaf.Subject.CommonName)
+	assert.Contains(t, logs.String(), `msg="TLS certificate reloaded" cert_file=server.crt subject="CN=b.example.com"`)
+
+	// key does not match the certificate, previous certificate is kept
+	certC, _ := newTestCertificate(t, "c.example.com", time.Now().Add(time.Hour))
+	modTime = modTime.Add(time.Minute)
+	writeTestCertificate(t, dir, "server", certC, keyB, modTime)
+	err = reloader.Reload()
+	assert.ErrorContains(t, err, "invalid certificate server.crt: tls: private key does not match public key")
+	cert, _ = reloader.GetCertificate(&tls.ClientHelloInfo{})
+	assert.Equal(t, "b.example.com", cert.Leaf.Subject.CommonName)
+	assert.Contains(t, logs.String(), `msg="failed to reload TLS certificate" cert_file=server.crt key_file=server.key`)
+
+	// expired certificate, previous certificate is kept
+	certD, keyD := newTestCertificate(t, "d.example.com", time.Now().Add(-1*time.Minute))
+	modTime = modTime.Add(time.Minute)
+	writeTestCertificate(t, dir, "server", certD, keyD, modTime)
+	assert.ErrorContains(t, reloader.Reload(), "invalid certificate server.crt: expired at")
+	cert, _ = reloader.GetCertificate(&tls.ClientHelloInfo{})
+	assert.Equal(t, "b.example.com", cert.Leaf.Subject.CommonName)
+}
+
+func TestCertificateReloader_GetCertificate(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"example.com", "example.org"} {
+		certPEM, keyPEM := newTestCertificate(t, name, time.Now().Add(time.Hour))
+		writeTestCertificate(t, dir, name, certPEM, keyPEM, time.Now())
+	}
+	reloader, err := NewCertificateReloader(os.DirFS(dir), nil,
+		CertificateFiles{CertFile: "example.com.crt", KeyFile: "example.com.key"},
+		CertificateFiles{CertFile: "example.org.crt", KeyFile: "example.org.key"},
+	)
+	require.NoError(t, err)
+
+	var testCases = []struct {
+		name       string
+		serverName string
+		expect     string
+	}{
+		{name: "ok, first certificate", serverName: "example.com", expect: "example.com"},
+		{name: "ok, second certificate", serverName: "example.org", expect: "example.org"},
+		{name: "ok, unknown name defaults to first certificate", serverName: "example.net", expect: "example.com"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hello := &tls.ClientHelloInfo{
+				ServerName:        tc.serverName,
+				SupportedVersions: []uint16{tls.VersionTLS13},
+				SignatureSchemes:  []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256},
+			}
+			cert, err := reloader.GetCertificate(hello)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, cert.Leaf.Subject.CommonName)
+		})
+	}
+}
+
+func TestNewCertificateReloader_error(t *testing.T) {
+	_, err := NewCertificateReloader(os.DirFS(t.TempDir()), nil, CertificateFiles{CertFile: "missing.crt", KeyFile: "missing.key"})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = NewCertificateReloader(os.DirFS(t.TempDir()), nil)
+	assert.EqualError(t, err, "certificate reloader: at least one certificate is required")
+}
+
+func TestStartConfig_StartTLS_certReload(t *testing.T) {
+	dir := t.TempDir()
+	modTime := time.Now().Add(-1 * time.Hour)
+	certA, keyA := newTestCertificate(t, "a.example.com", time.Now().Add(time.Hour))
+	writeTestCertificate(t, dir, "server", certA, keyA, modTime)
+	certOrg, keyOrg := newTestCertificate(t, "example.org", time.Now().Add(time.Hour))
+	writeTestCertificate(t, dir, "org", certOrg, keyOrg, modTime)
+
+	e := New()
+	logs := new(syncBuffer)
+	e.Logger = slog.New(slog.NewTextHandler(logs, nil))
+	e.GET("/ok", func(c *Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	addrChan := make(chan string)
+	errCh := make(chan error)
+	ctx, shutdown := stdContext.WithTimeout(stdContext.Background(), 2*time.Second)
+	defer shutdown()
+	go func() {
+		errCh <- (&StartConfig{
+			Address:            "127.0.0.1:0",
+			CertFilesystem:     os.DirFS(dir),
+			CertFiles:          []CertificateFiles{{CertFile: "org.crt", KeyFile: "org.key"}},
+			CertReloadInterval: 10 * time.Millisecond,
+			GracefulTimeout:    100 * time.Millisecond,
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+		}).StartTLS(ctx, e, "server.crt", "server.key")
+	}()
+	addr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+
+	servedCertificate := func(serverName string) string {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: serverName, InsecureSkipVerify: true})
+		if err != nil {
+			return err.Error()
+		}
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	}
+	assert.Equal(t, "a.example.com", servedCertificate("a.example.com"))
+	assert.Equal(t, "example.org", servedCertificate("example.org"))
+
+	certB, keyB := newTestCertificate(t, "b.example.com", time.Now().Add(time.Hour))
+	writeTestCertificate(t, dir, "server", certB, keyB, modTime.Add(time.Minute))
+
+	assert.Eventually(t, func() bool {
+		return servedCertificate("b.example.com") == "b.example.com"
+	}, time.Second, 10*time.Millisecond)
+	assert.Contains(t, logs.String(), `msg="TLS certificate reloaded" cert_file=server.crt subject="CN=b.example.com"`)
+	assert.Equal(t, "example.org", servedCertificate("example.org"))
+
+	shutdown()
+	assert.NoError(t, <-errCh)
+}
+
+func TestStartConfig_StartTLS_certReloadRequiresPaths(t *testing.T) {
+	certPEM, keyPEM := newTestCertificate(t, "example.com", time.Now().Add(time.Hour))
+
+	err := (&StartConfig{Address: "127.0.0.1:0", CertReloadInterval: time.Second}).
+		StartTLS(stdContext.Background(), New(), certPEM, keyPEM)
+	assert.ErrorIs(t, err, ErrInvalidCertOrKeyType)
+	assert.EqualError(t, err, fmt.Sprintf("%v: certificate reloading requires file paths", ErrInvalidCertOrKeyType))
+}
+
+func TestStartConfig_StartTLS_clientAuth(t *testing.T) {
+	dir := t.TempDir()
+	serverCert, serverKey := newTestCertificate(t, "localhost", time.Now().Add(time.Hour))
+	writeTestCertificate(t, dir, "server", serverCert, serverKey, time.Now())
+	clientCert, clientKey := newTestCertificate(t, "client", time.Now().Add(time.Hour))
+	writeTestCertificate(t, dir, "client", clientCert, clientKey, time.Now())
+
+	e := New()
+	e.GET("/whoami", func(c *Context) error {
+		return c.String(http.StatusOK, c.Request().TLS.PeerCertificates[0].Subject.CommonName)
+	})
+
+	addrChan := make(chan string)
+	errCh := make(chan error)
+	ctx, shutdown := stdContext.WithTimeout(stdContext.Background(), 2*time.Second)
+	defer shutdown()
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	go func() {
+		errCh <- (&StartConfig{
+			Address:         "127.0.0.1:0",
+			HideBanner:      true,
+			HidePort:        true,
+			TLSConfig:       tlsConfig,
+			CertFilesystem:  os.DirFS(dir),
+			ClientCAFiles:   []string{"client.crt"},
+			GracefulTimeout: 100 * time.Millisecond,
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+		}).StartTLS(ctx, e, "server.crt", "server.key")
+	}()
+	addr, err := waitForServerStart(addrChan, errCh)
+	require.NoError(t, err)
+	url := fmt.Sprintf("https://%v/whoami", addr)
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+	_, err = client.Get(url)
+	assert.ErrorContains(t, err, "certificate required")
+
+	cert, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	client.Transport = &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true, Certificates: []tls.Certificate{cert}},
+	}
+	res, err := client.Get(url)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "client", string(body))
+
+	// TLS config given by the caller is not changed
+	assert.Nil(t, tlsConfig.ClientCAs)
+	assert.Equal(t, tls.NoClientCert, tlsConfig.ClientAuth)
+	assert.Empty(t, tlsConfig.Certificates)
+}
+
+func TestStartConfig_configureClientAuth(t *testing.T) {
+	certPEM, _ := newTestCertificate(t, "client", time.Now().Add(time.Hour))
+	certFs := fstest.MapFS{
+		"ca.crt":      &fstest.MapFile{Data: certPEM},
+		"invalid.crt": &fstest.MapFile{Data: []byte("not a certificate")},
+	}
+	var testCases = []struct {
+		name             string
+		givenConfig      StartConfig
+		expectClientAuth tls.ClientAuthType
+		expectCAs        bool
+		expectError      string
+	}{
+		{
+			name:             "ok, no client auth",
+			expectClientAuth: tls.NoClientCert,
+		},
+		{
+			name:             "ok, CA files default to require and verify",
+			givenConfig:      StartConfig{ClientCAFiles: []string{"ca.crt"}},
+			expectClientAuth: tls.RequireAndVerifyClientCert,
+			expectCAs:        true,
+		},
+		{
+			name:             "ok, CA pool with verify if given",
+			givenConfig:      StartConfig{ClientCAs: x509.NewCertPool(), ClientAuth: tls.VerifyClientCertIfGiven},
+			expectClientAuth: tls.VerifyClientCertIfGiven,
+			expectCAs:        true,
+		},
+		{
+			name:             "ok, request any certificate",
+			givenConfig:      StartConfig{ClientAuth: tls.RequestClientCert},
+			expectClientAuth: tls.RequestClientCert,
+		},
+		{
+			name:        "nok, missing CA file",
+			givenConfig: StartConfig{ClientCAFiles: []string{"missing.crt"}},
+			expectError: "open missing.crt: file does not exist",
+		},
+		{
+			name:        "nok, CA file without certificates",
+			givenConfig: StartConfig{ClientCAFiles: []string{"invalid.crt"}},
+			expectError: "client CA file invalid.crt does not contain PEM encoded certificates",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tlsConfig := &tls.Config{}
+			err := tc.givenConfig.configureClientAuth(tlsConfig, certFs)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectClientAuth, tlsConfig.ClientAuth)
+			assert.Equal(t, tc.expectCAs, tlsConfig.ClientCAs != nil)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	stdContext "context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSSEKeepAliveInterval is default interval of keep-alive comments sent by Context.SSE.
+const DefaultSSEKeepAliveInterval = 15 * time.Second
+
+// SSEConfig is configuration for Context.SSEWithConfig.
+type SSEConfig struct {
+	// KeepAliveInterval is interval of keep-alive comments sent to the client while stream is open. Keep-alive comments
+	// prevent proxies and load balancers from closing idle connections.
+	// Optional. Default value DefaultSSEKeepAliveInterval. Negative value disables keep-alive comments.
+	KeepAliveInterval time.Duration
+
+	// Retry is reconnection time sent to the client at the start of the stream.
+	// Optional. Default value 0 (not sent).
+	Retry time.Duration
+}
+
+// SSEEvent is single Server-Sent Event.
+// See: https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+type SSEEvent struct {
+	// ID sets the event ID which client sends back with `Last-Event-ID` header when reconnecting.
+	ID string
+	// Event is event type. When empty client dispatches event as `message`.
+	Event string
+	// Data is event payload. Multi-line data is sent as multiple `data` fields.
+	Data string
+	// Retry is reconnection time for the client. Zero value is not sent.
+	Retry time.Duration
+}
+
+// SSEWriter writes Server-Sent Events to the client. Writer methods are safe to be called from multiple goroutines.
+type SSEWriter struct {
+	ctx         stdContext.Context
+	done        chan struct{}
+	lastEventID string
+	response    http.ResponseWriter
+	rc          *http.ResponseController
+
+	mu     sync.Mutex
+	buf    []byte
+	closed bool
+}
+
+// ErrSSEStreamClosed is returned when writing to the SSEWriter after Context.SSE callback function has returned.
+var ErrSSEStreamClosed = errors.New("sse stream is closed")
+
+var sseFieldReplacer = strings.NewReplacer("\r", "", "\n", "")
+
+// SSE starts Server-Sent Events stream and calls fn with writer to send events. Stream ends when fn returns. Function
+// should return when SSEWriter.Done channel is closed - this happens when client disconnects (request context is
+// cancelled) or server started with StartConfig is shutting down.
+//
+// Events are flushed to the client after each write through Context.Response() so middlewares wrapping the response
+// (i.e. Gzip, BodyDump) must support flushing (http.Flusher or `Unwrap() http.ResponseWriter`).
+//
+// Note: http.Server.WriteTimeout applies to the whole stream, so set it to 0 (or use http.ResponseController to
+// extend write deadline) when serving long-lived streams.
+func (c *Context) SSE(fn func(w *SSEWriter) error) error {
+	return c.SSEWithConfig(SSEConfig{}, fn)
+}
+
+// SSEWithConfig starts Server-Sent Events stream with given configuration. See Context.SSE.
+func (c *Context) SSEWithConfig(config SSEConfig, fn func(w *SSEWriter) error) error {
+	if config.KeepAliveInterval == 0 {
+		config.KeepAliveInterval = DefaultSSEKeepAliveInterval
+	}
+
+	header := c.Response().Header()
+	header.Set(HeaderContentType, MIMETextEventStream)
+	header.Set(HeaderCacheControl, "no-cache")
+	header.Set("X-Accel-Buffering", "no") // disable response buffering in nginx
+	header.Del(HeaderContentLength)
+
+	ctx := c.Request().Context()
+	w := &SSEWriter{
+		ctx:         ctx,
+		done:        make(chan struct{}),
+		lastEventID: c.Request().Header.Get(HeaderLastEventID),
+		response:    c.Response(),
+		rc:          http.NewResponseController(c.Response()),
+	}
+	c.Response().WriteHeader(http.StatusOK)
+
+	err := w.write(func(b []byte) []byte {
+		if config.Retry <= 0 {
+			return b
+		}
+		return append(appendSSERetry(b, config.Retry), '\n')
+	})
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	shutdown := serverShutdownSignal(ctx)
+	wg.Go(func() {
+		select {
+		case <-ctx.Done():
+		case <-shutdown:
+		case <-stop:
+		}
+		close(w.done)
+	})
+	if config.KeepAliveInterval > 0 {
+		wg.Go(func() {
+			w.keepAlive(config.KeepAliveInterval)
+		})
+	}
+	defer func() {
+		w.mu.Lock()
+		w.closed = true
+		w.mu.Unlock()
+		close(stop)
+		wg.Wait()
+	}()
+
+	return fn(w)
+}
+
+// LastEventID returns value of the `Last-Event-ID` request header which client sends when reconnecting to the stream.
+func (w *SSEWriter) LastEventID() string {
+	return w.lastEventID
+}
+
+// Done returns channel that is closed when client disconnects or server is shutting down. Stream function should
+// return when Done is closed.
+func (w *SSEWriter) Done() <-chan struct{} {
+	return w.done
+}
+
+// Send writes event to the client and flushes it.
+func (w *SSEWriter) Send(event SSEEvent) error {
+	return w.write(func(b []byte) []byte {
+		return appendSSEEvent(b, event)
+	})
+}
+
+// SendData writes event with only data field to the client and flushes it.
+func (w *SSEWriter) SendData(data string) error {
+	return w.Send(SSEEvent{Data: data})
+}
+
+// Comment writes comment line to the client and flushes it. Comments are ignored by clients and are used as
+// keep-alive messages.
+func (w *SSEWriter) Comment(comment string) error {
+	return w.write(func(b []byte) []byte {
+		b = append(b, ':')
+		if comment != "" {
+			b = append(b, ' ')
+			b = append(b, sseFieldReplacer.Replace(comment)...)
+		}
+		return append(b, '\n', '\n')
+	})
+}
+
+func (w *SSEWriter) write(appendFn func(b []byte) []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrSSEStreamClosed
+	}
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	w.buf = appendFn(w.buf[:0])
+	if len(w.buf) > 0 {
+		if _, err := w.response.Write(w.buf); err != nil {
+			return err
+		}
+	}
+	return w.rc.Flush()
+}
+
+func (w *SSEWriter) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			if err := w.Comment(""); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func appendSSEEvent(b []byte, event SSEEvent) []byte {
+	if event.ID != "" {
+		b = appendSSEField(b, "id", sseFieldReplacer.Replace(event.ID))
+	}
+	if event.Event != "" {
+		b = appendSSEField(b, "event", sseFieldReplacer.Replace(event.Event))
+	}
+	if event.Retry > 0 {
+		b = appendSSERetry(b, event.Retry)
+	}
+	// CRLF, LF and bare CR are all line terminators in event stream, so data can not inject other fields. Every line,
+	// including empty trailing one, is sent as separate data field so client receives data unchanged.
+	data := event.Data
+	for {
+		i := strings.IndexAny(data, "\r\n")
+		if i == -1 {
+			b = appendSSEField(b, "data", data)
+			break
+		}
+		b = appendSSEField(b, "data", data[:i])
+		if data[i] == '\r' && i+1 < len(data) && data[i+1] == '\n' {
+			i++
+		}
+		data = data[i+1:]
+	}
+	return append(b, '\n')
+}
+
+func appendSSERetry(b []byte, retry time.Duration) []byte {
+	b = append(b, "retry: "...)
+	b = strconv.AppendInt(b, retry.Milliseconds(), 10)
+	return append(b, '\n')
+}
+
+func appendSSEField(b []byte, name string, value string) []byte {
+	b = append(b, name...)
+	b = append(b, ':', ' ')
+	b = append(b, value...)
+	return append(b, '\n')
+}
@@ -0,0 +1,217 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"bufio"
+	stdContext "context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendSSEEvent(t *testing.T) {
+	var testCases = []struct {
+		name   string
+		when   SSEEvent
+		expect string
+	}{
+		{
+			name:   "ok, data only",
+			when:   SSEEvent{Data: "hello"},
+			expect: "data: hello\n\n",
+		},
+		{
+			name:   "ok, empty data",
+			when:   SSEEvent{Event: "ping"},
+			expect: "event: ping\ndata: \n\n",
+		},
+		{
+			name:   "ok, all fields",
+			when:   SSEEvent{ID: "42", Event: "update", Data: "payload", Retry: 3 * time.Second},
+			expect: "id: 42\nevent: update\nretry: 3000\ndata: payload\n\n",
+		},
+		{
+			name:   "ok, multi-line data",
+			when:   SSEEvent{Data: "line1\nline2\r\nline3"},
+			expect: "data: line1\ndata: line2\ndata: line3\n\n",
+		},
+		{
+			name:   "ok, bare CR in data is line break and can not inject fields",
+			when:   SSEEvent{Data: "x\revent: admin\r\rid: 1\r"},
+			expect: "data: x\ndata: event: admin\ndata: \ndata: id: 1\ndata: \n\n",
+		},
+		{
+			name:   "ok, trailing line break is sent as empty data line",
+			when:   SSEEvent{Data: "a\n"},
+			expect: "data: a\ndata: \n\n",
+		},
+		{
+			name:   "ok, line breaks are removed from id and event",
+			when:   SSEEvent{ID: "4\n2", Event: "up\r\ndate", Data: "x"},
+			expect: "id: 42\nevent: update\ndata: x\n\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, string(appendSSEEvent(nil, tc.when)))
+		})
+	}
+}
+
+func TestContext_SSE(t *testing.T) {
+	e := New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderLastEventID, "41")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var lastEventID string
+	err := c.SSEWithConfig(SSEConfig{Retry: 2 * time.Second, KeepAliveInterval: -1}, func(w *SSEWriter) error {
+		lastEventID = w.LastEventID()
+		if err := w.Send(SSEEvent{ID: "42", Event: "update", Data: "a\nb"}); err != nil {
+			return err
+		}
+		if err := w.Comment("hi"); err != nil {
+			return err
+		}
+		return w.SendData("c")
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "41", lastEventID)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, MIMETextEventStream, rec.Header().Get(HeaderContentType))
+	assert.Equal(t, "no-cache", rec.Header().Get(HeaderCacheControl))
+	assert.Equal(t, "retry: 2000\n\nid: 42\nevent: update\ndata: a\ndata: b\n\n: hi\n\ndata: c\n\n", rec.Body.String())
+}
+
+func TestContext_SSE_writeAfterReturn(t *testing.T) {
+	e := New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	var writer *SSEWriter
+	err := c.SSE(func(w *SSEWriter) error {
+		writer = w
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, ErrSSEStreamClosed, writer.SendData("late"))
+	select {
+	case <-writer.Done():
+	default:
+		t.Fatal("done channel should be closed after stream function returns")
+	}
+}
+
+func TestContext_SSE_requestContextCancelled(t *testing.T) {
+	e := New()
+	ctx, cancel := stdContext.WithCancel(stdContext.Background())
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	err := c.SSE(func(w *SSEWriter) error {
+		cancel()
+		<-w.Done()
+		return w.SendData("x")
+	})
+	assert.ErrorIs(t, err, stdContext.Canceled)
+}
+
+func TestContext_SSE_keepAlive(t *testing.T) {
+	e := New()
+	e.GET("/events", func(c *Context) error {
+		return c.SSEWithConfig(SSEConfig{KeepAliveInterval: 10 * time.Millisecond}, func(w *SSEWriter) error {
+			<-w.Done()
+			return nil
+		})
+	})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	ctx, cancel := stdContext.WithTimeout(stdContext.Background(), time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, ":\n", line)
+}
+
+func TestContext_SSE_gracefulShutdown(t *testing.T) {
+	e := New()
+	e.Logger = slog.New(slog.DiscardHandler)
+
+	streamEnded := make(chan error, 1)
+	e.GET("/events", func(c *Context) error {
+		err := c.SSEWithConfig(SSEConfig{KeepAliveInterval: -1}, func(w *SSEWriter) error {
+			if err := w.SendData("started"); err != nil {
+				return err
+			}
+			<-w.Done()
+			return w.Send(SSEEvent{Event: "shutdown"})
+		})
+		streamEnded <- err
+		return err
+	})
+
+	ctx, shutdown := stdContext.WithCancel(stdContext.Background())
+	addrChan := make(chan string)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- StartConfig{
+			Address:         ":0",
+			HideBanner:      true,
+			HidePort:        true,
+			GracefulTimeout: 5 * time.Second,
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+		}.Start(ctx, e)
+	}()
+	addr, err := waitForServerStart(addrChan, errCh)
+	if !assert.NoError(t, err) {
+		shutdown()
+		return
+	}
+
+	resp, err := http.Get("http://" + addr + "/events")
+	if !assert.NoError(t, err) {
+		shutdown()
+		return
+	}
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "data: started\n", line)
+
+	start := time.Now()
+	shutdown()
+
+	select {
+	case err := <-streamEnded:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not end on graceful shutdown")
+	}
+	assert.NoError(t, <-errCh)
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	rest, _ := io.ReadAll(reader)
+	assert.Equal(t, "\nevent: shutdown\ndata: \n\n", string(rest))
+}
@@ -0,0 +1,788 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// StructValidator is dependency-free Validator implementation that validates struct fields by rules in the `validate`
+// struct tag. Rules are separated by comma and rule parameter is written after equals sign, for example
+// `validate:"required,min=1,max=64"` or `validate:"omitempty,oneof=draft published"`.
+//
+// Built-in rules are:
+//   - `required` - value must not be zero value. Pointers must not be nil, slices and maps must not be empty.
+//   - `min=N`, `max=N`, `len=N` - numbers are compared to N, strings by their length in characters and slices,
+//     arrays and maps by their number of elements.
+//   - `eq=V`, `ne=V` - value must (not) be equal to V.
+//   - `oneof=A B C` - value must be one of space separated values.
+//   - `email`, `url`, `uuid`, `alpha`, `alnum`, `numeric` - string must have given format.
+//   - `eqfield=F`, `nefield=F`, `gtfield=F`, `gtefield=F`, `ltfield=F`, `ltefield=F` - value is compared to the
+//     value of field F (Go field name) of the same struct. Numbers, strings and time.Time can be compared.
+//
+// Special rules are:
+//   - `omitempty` - rules are not checked for zero value.
+//   - `dive` - rules after `dive` are checked for every element of slice, array or map.
+//   - `-` - field and its nested structs are not validated.
+//
+// Rules other than `required` are not checked for nil pointers. Nested structs, pointers to structs and slices, arrays
+// and maps of them are validated recursively. All fields are validated and errors are returned as ValidationErrors
+// that lists every field that failed validation. Fields are named in errors by their `json`, `form`, `query`, `param`
+// or `header` tag name (first that exists) or by Go field name (`address.city`, `items[0].sku`).
+//
+// Example:
+//
+//	e.Validator = &echo.StructValidator{
+//		Rules: map[string]echo.ValidationRule{"sku": isSKU},
+//	}
+type StructValidator struct {
+	// Rules are custom named validation rules. Built-in rules have priority over custom rules with the same name.
+	Rules map[string]ValidationRule
+}
+
+// ValidationRule reports whether field value satisfies the rule.
+type ValidationRule func(field ValidationField) bool
+
+// ValidationField is the field that is validated by ValidationRule.
+type ValidationField struct {
+	// Value is the value of the field with pointers dereferenced.
+	Value reflect.Value
+	// Param is the rule parameter (`5` for `min=5`). Empty when rule has no parameter.
+	Param string
+	// Parent is the struct that contains the field. It is used by rules that compare field to other fields.
+	Parent reflect.Value
+}
+
+// ValidationError is an error of single field that failed validation.
+type ValidationError struct {
+	// Field is the key path of the field (`address.city`, `items[0].sku`).
+	Field string `json:"field"`
+	// Rule is the name of the rule that field failed.
+	Rule string `json:"rule"`
+	// Param is the parameter of the rule.
+	Param string `json:"param,omitempty"`
+	// Message describes why the field failed validation.
+	Message string `json:"message"`
+}
+
+// Error returns error message
+func (ve *ValidationError) Error() string {
+	return fmt.Sprintf("field=%s, rule=%s, message=%s", ve.Field, ve.Rule, ve.Message)
+}
+
+// ValidationErrors is returned by StructValidator when one or more fields failed validation. JSON serialization lists
+// errors of all fields (e.g. {"message":"Bad Request","errors":[{"field":"email","rule":"email","message":"..."}]})
+// and RFC 9457 problem details list them in the "errors" extension member.
+type ValidationErrors struct {
+	*HTTPError
+	// Errors are errors of fields that failed validation in the order of struct fields.
+	Errors []*ValidationError `json:"errors"`
+}
+
+// NewValidationErrors creates new instance of bad request error for fields that failed validation
+func NewValidationErrors(errs []*ValidationError) *ValidationErrors {
+	return &ValidationErrors{
+		HTTPError: &HTTPError{Code: http.StatusBadRequest},
+		Errors:    errs,
+	}
+}
+
+// Error returns error message
+func (ve *ValidationErrors) Error() string {
+	messages := make([]string, len(ve.Errors))
+	for i, err := range ve.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%s, errors=[%s]", ve.HTTPError.Error(), strings.Join(messages, "; "))
+}
+
+// Unwrap returns errors of fields that failed validation.
+func (ve *ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve.Errors))
+	for i, err := range ve.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// MarshalJSON implements json.Marshaler so that DefaultHTTPErrorHandler responds with list of all
+// fields that failed validation.
+func (ve *ValidationErrors) MarshalJSON() ([]byte, error) {
+	message := ve.Message
+	if message == "" {
+		message = http.StatusText(ve.Code)
+	}
+	return json.Marshal(struct {
+		Message string             `json:"message"`
+		Errors  []*ValidationError `json:"errors"`
+	}{
+		Message: message,
+		Errors:  ve.Errors,
+	})
+}
+
+// ProblemError implements ProblemErrorer so that ProblemDetailsHTTPErrorHandler responds with list of all
+// fields that failed validation.
+func (ve *ValidationErrors) ProblemError() *ProblemError {
+	return &ProblemError{
+		Status: ve.Code,
+		Detail: ve.Message,
+		Errors: ve.Errors,
+	}
+}
+
+// Validate validates struct, pointer to struct or slice of them. Returns ValidationErrors when fields failed
+// validation or error wrapping ErrInvalidValidationTag when `validate` tag is invalid.
+func (v *StructValidator) Validate(i any) error {
+	s := validation{rules: v.Rules}
+	if err := s.value(reflect.ValueOf(i), ""); err != nil {
+		return err
+	}
+	if len(s.errors) > 0 {
+		return NewValidationErrors(s.errors)
+	}
+	return nil
+}
+
+const (
+	validateRuleRequired  = "required"
+	validateRuleOmitEmpty = "omitempty"
+	validateRuleDive      = "dive"
+)
+
+var builtinValidationRules = map[string]ValidationRule{
+	validateRuleRequired: validateRequired,
+	"min":                validateMin,
+	"max":                validateMax,
+	"len":                validateLen,
+	"eq":                 validateEq,
+	"ne":                 validateNe,
+	"oneof":              validateOneOf,
+	"email":              validateEmail,
+	"url":                validateURL,
+	"uuid":               validateUUID,
+	"alpha":              validateAlpha,
+	"alnum":              validateAlnum,
+	"numeric":            validateNumeric,
+	"eqfield":            validateEqField,
+	"nefield":            validateNeField,
+	"gtfield":            validateGtField,
+	"gtefield":           validateGteField,
+	"ltfield":            validateLtField,
+	"ltefield":           validateLteField,
+}
+
+// validateRule is parsed rule of the `validate` tag.
+type validateRule struct {
+	name  string
+	param string
+	// paramField is the error name of the field that cross-field rule compares to.
+	paramField string
+}
+
+type validateFieldMeta struct {
+	index int
+	// name is the name of the field in error key path
+	name      string
+	embedded  bool
+	omitEmpty bool
+	rules     []validateRule
+	// dive is true when rules after `dive` are checked for elements of the field.
+	dive          bool
+	diveOmitEmpty bool
+	diveRules     []validateRule
+	// nested is true when field or its elements can contain structs that are validated recursively.
+	nested bool
+}
+
+type validateStructMeta struct {
+	fields []validateFieldMeta
+	// err is the error of invalid `validate` tag. It is returned every time the struct is validated.
+	err error
+}
+
+// validateStructCache holds parsed `validate` tags by struct type.
+var validateStructCache sync.Map // map[reflect.Type]*validateStructMeta
+
+func validateMetaFor(typ reflect.Type) *validateStructMeta {
+	if cached, ok := validateStructCache.Load(typ); ok {
+		return cached.(*validateStructMeta)
+	}
+	meta := &validateStructMeta{}
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		tag := f.Tag.Get("validate")
+		embedded := f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct
+		if tag == "-" || (!f.IsExported() && !embedded) {
+			continue
+		}
+		fm := validateFieldMeta{
+			index:    i,
+			name:     validateFieldName(f),
+			embedded: embedded,
+			nested:   mayContainStruct(f.Type),
+		}
+		if err := fm.parseTag(typ, f, tag); err != nil && meta.err == nil {
+			meta.err = fmt.Errorf("%w: field %s: %w", ErrInvalidValidationTag, f.Name, err)
+		}
+		meta.fields = append(meta.fields, fm)
+	}
+	validateStructCache.Store(typ, meta)
+	return meta
+}
+
+func (fm *validateFieldMeta) parseTag(parent reflect.Type, f reflect.StructField, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	typ := indirectType(f.Type)
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(part, "=")
+		switch name {
+		case "":
+			return fmt.Errorf("empty rule in %q", tag)
+		case validateRuleDive:
+			if fm.dive {
+				return fmt.Errorf("rule %s can be used only once", name)
+			}
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+			default:
+				return fmt.Errorf("rule %s can not be used with %s", name, typ)
+			}
+			fm.dive = true
+			typ = indirectType(typ.Elem())
+			continue
+		case validateRuleOmitEmpty:
+			if fm.dive {
+				fm.diveOmitEmpty = true
+			} else {
+				fm.omitEmpty = true
+			}
+			continue
+		}
+		rule := validateRule{name: name, param: param}
+		if err := checkValidateRule(parent, typ, &rule); err != nil {
+			return err
+		}
+		if fm.dive {
+			fm.diveRules = append(fm.diveRules, rule)
+		} else {
+			fm.rules = append(fm.rules, rule)
+		}
+	}
+	return nil
+}
+
+// checkValidateRule checks that built-in rule can be used with field type and that its parameter is valid. Custom
+// rules are not checked.
+func checkValidateRule(parent reflect.Type, typ reflect.Type, rule *validateRule) error {
+	switch rule.name {
+	case "min", "max", "len":
+		if _, err := strconv.ParseFloat(rule.param, 64); err != nil {
+			return fmt.Errorf("rule %s has invalid parameter: %w", rule.name, err)
+		}
+		if _, ok := validationSize(reflect.New(typ).Elem()); !ok {
+			return fmt.Errorf("rule %s can not be used with %s", rule.name, typ)
+		}
+	case "email", "url", "uuid", "alpha", "alnum", "numeric":
+		if typ.Kind() != reflect.String {
+			return fmt.Errorf("rule %s can be used only with strings", rule.name)
+		}
+	case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+		other, ok := parent.FieldByName(rule.param)
+		if !ok {
+			return fmt.Errorf("rule %s refers to unknown field %q", rule.name, rule.param)
+		}
+		rule.paramField = validateFieldName(other)
+	}
+	return nil
+}
+
+// validateFieldName returns name of the field for error key path.
+func validateFieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "query", "param", "header"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// mayContainStruct reports whether value of type can contain structs that are validated recursively.
+func mayContainStruct(typ reflect.Type) bool {
+	typ = indirectType(typ)
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return mayContainStruct(typ.Elem())
+	default:
+		return false
+	}
+}
+
+// validation holds state of single Validate call.
+type validation struct {
+	rules  map[string]ValidationRule
+	errors []*ValidationError
+	// visiting holds pointers, maps and slices that are being validated, so self-referencing values (i.e. parent and
+	// child pointing to each other) are validated once instead of recursing endlessly.
+	visiting map[visitKey]struct{}
+}
+
+// visitKey identifies pointer, map or slice value. Type is part of the key as pointer to struct and pointer to its
+// first field have the same address.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// enter marks pointer, map or slice value as being validated. Returns false when the value is already being validated
+// by one of the callers.
+func (s *validation) enter(key visitKey) bool {
+	if _, ok := s.visiting[key]; ok {
+		return false
+	}
+	if s.visiting == nil {
+		s.visiting = make(map[visitKey]struct{})
+	}
+	s.visiting[key] = struct{}{}
+	return true
+}
+
+// value validates structs in v (struct, pointer to struct, slice, array or map of them) under key path.
+func (s *validation) value(v reflect.Value, path string) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Pointer {
+			key := visitKey{ptr: v.Pointer(), typ: v.Type()}
+			if !s.enter(key) {
+				return nil
+			}
+			defer delete(s.visiting, key)
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return s.structValue(v, path)
+	case reflect.Slice, reflect.Array:
+		if !mayContainStruct(v.Type().Elem()) {
+			return nil
+		}
+		if v.Kind() == reflect.Slice {
+			key := visitKey{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}
+			if !s.enter(key) {
+				return nil
+			}
+			defer delete(s.visiting, key)
+		}
+		for i := range v.Len() {
+			if err := s.value(v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !mayContainStruct(v.Type().Elem()) {
+			return nil
+		}
+		mapKey := visitKey{ptr: v.Pointer(), typ: v.Type()}
+		if !s.enter(mapKey) {
+			return nil
+		}
+		defer delete(s.visiting, mapKey)
+		for _, key := range sortedMapKeys(v) {
+			if err := s.value(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *validation) structValue(v reflect.Value, path string) error {
+	meta := validateMetaFor(v.Type())
+	if meta.err != nil {
+		return meta.err
+	}
+	for i := range meta.fields {
+		fm := &meta.fields[i]
+		field := v.Field(fm.index)
+		if fm.embedded {
+			if err := s.value(field, path); err != nil {
+				return err
+			}
+			continue
+		}
+		fieldPath := fm.name
+		if path != "" {
+			fieldPath = path + "." + fm.name
+		}
+
+		ok, err := s.check(field, v, fieldPath, fm.rules, fm.omitEmpty)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue // nested structs of invalid field are not validated
+		}
+		if !fm.dive {
+			if fm.nested {
+				if err := s.value(field, fieldPath); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		for field.Kind() == reflect.Pointer && !field.IsNil() {
+			field = field.Elem()
+		}
+		var elems []reflect.Value
+		var elemPaths []string
+		switch field.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := range field.Len() {
+				elems = append(elems, field.Index(i))
+				elemPaths = append(elemPaths, fieldPath+"["+strconv.Itoa(i)+"]")
+			}
+		case reflect.Map:
+			for _, key := range sortedMapKeys(field) {
+				elems = append(elems, field.MapIndex(key))
+				elemPaths = append(elemPaths, fmt.Sprintf("%s[%v]", fieldPath, key))
+			}
+		}
+		for i, elem := range elems {
+			ok, err := s.check(elem, v, elemPaths[i], fm.diveRules, fm.diveOmitEmpty)
+			if err != nil {
+				return err
+			}
+			if ok && fm.nested {
+				if err := s.value(elem, elemPaths[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// check checks field value against rules and adds error for the first rule that fails. Returns false when rule
+// failed.
+func (s *validation) check(field reflect.Value, parent reflect.Value, path string, rules []validateRule, omitEmpty bool) (bool, error) {
+	if omitEmpty && isEmptyValidationValue(field) {
+		return true, nil
+	}
+	isPointer := field.Kind() == reflect.Pointer
+	for field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			if len(rules) > 0 && rules[0].name == validateRuleRequired {
+				s.addError(path, rules[0], field)
+				return false, nil
+			}
+			return true, nil // other rules are not checked for nil pointers
+		}
+		field = field.Elem()
+	}
+
+	for _, r := range rules {
+		if r.name == validateRuleRequired && isPointer {
+			continue // pointer that is not nil satisfies required
+		}
+		rule, ok := builtinValidationRules[r.name]
+		if !ok {
+			if rule, ok = s.rules[r.name]; !ok || rule == nil {
+				return false, fmt.Errorf("%w: field %s: unknown rule %q", ErrInvalidValidationTag, path, r.name)
+			}
+		}
+		if !rule(ValidationField{Value: field, Param: r.param, Parent: parent}) {
+			s.addError(path, r, field)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *validation) addError(path string, rule validateRule, value reflect.Value) {
+	s.errors = append(s.errors, &ValidationError{
+		Field:   path,
+		Rule:    rule.name,
+		Param:   rule.param,
+		Message: validationMessage(rule, value),
+	})
+}
+
+// validationMessage returns message for value that failed the rule.
+func validationMessage(rule validateRule, value reflect.Value) string {
+	var unit string
+	switch value.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " elements"
+	}
+	switch rule.name {
+	case validateRuleRequired:
+		return "is required"
+	case "min":
+		if unit != "" {
+			return "must have at least " + rule.param + unit
+		}
+		return "must be at least " + rule.param
+	case "max":
+		if unit != "" {
+			return "must have at most " + rule.param + unit
+		}
+		return "must be at most " + rule.param
+	case "len":
+		if unit != "" {
+			return "must have exactly " + rule.param + unit
+		}
+		return "must be " + rule.param
+	case "eq":
+		return "must be equal to " + rule.param
+	case "ne":
+		return "must not be equal to " + rule.param
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(rule.param), ", ")
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "uuid":
+		return "must be a valid UUID"
+	case "alpha":
+		return "must contain only letters"
+	case "alnum":
+		return "must contain only letters and digits"
+	case "numeric":
+		return "must be a number"
+	case "eqfield":
+		return "must be equal to " + rule.paramField
+	case "nefield":
+		return "must not be equal to " + rule.paramField
+	case "gtfield":
+		return "must be greater than " + rule.paramField
+	case "gtefield":
+		return "must be greater than or equal to " + rule.paramField
+	case "ltfield":
+		return "must be less than " + rule.paramField
+	case "ltefield":
+		return "must be less than or equal to " + rule.paramField
+	default:
+		return "failed " + rule.name + " validation"
+	}
+}
+
+func isEmptyValidationValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	return keys
+}
+
+// validationSize returns number value or length of value for size rules (`min`, `max`, `len`).
+func validationSize(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true
+	default:
+		return 0, false
+	}
+}
+
+// validationString returns value formatted as string for rules that compare value to parameter (`eq`, `oneof`).
+func validationString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// compareValidationValues compares two values of the same kind. Returns false when values can not be compared.
+func compareValidationValues(a reflect.Value, b reflect.Value) (int, bool) {
+	for b.Kind() == reflect.Pointer {
+		if b.IsNil() {
+			return 0, false
+		}
+		b = b.Elem()
+	}
+	if a.Type() != b.Type() {
+		return 0, false
+	}
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Compare(b.Interface().(time.Time)), true
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), true
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String()), true
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// compareField compares field value to value of the field named by rule parameter.
+func compareField(f ValidationField) (int, bool) {
+	if !f.Parent.IsValid() || f.Parent.Kind() != reflect.Struct {
+		return 0, false
+	}
+	other := f.Parent.FieldByName(f.Param)
+	if !other.IsValid() {
+		return 0, false
+	}
+	return compareValidationValues(f.Value, other)
+}
+
+func validateRequired(f ValidationField) bool {
+	return !isEmptyValidationValue(f.Value)
+}
+
+func validateMin(f ValidationField) bool {
+	size, ok := validationSize(f.Value)
+	limit, err := strconv.ParseFloat(f.Param, 64)
+	return ok && err == nil && size >= limit
+}
+
+func validateMax(f ValidationField) bool {
+	size, ok := validationSize(f.Value)
+	limit, err := strconv.ParseFloat(f.Param, 64)
+	return ok && err == nil && size <= limit
+}
+
+func validateLen(f ValidationField) bool {
+	size, ok := validationSize(f.Value)
+	limit, err := strconv.ParseFloat(f.Param, 64)
+	return ok && err == nil && size == limit
+}
+
+func validateEq(f ValidationField) bool {
+	return validationString(f.Value) == f.Param
+}
+
+func validateNe(f ValidationField) bool {
+	return validationString(f.Value) != f.Param
+}
+
+func validateOneOf(f ValidationField) bool {
+	return slices.Contains(strings.Fields(f.Param), validationString(f.Value))
+}
+
+func validateEmail(f ValidationField) bool {
+	addr, err := mail.ParseAddress(f.Value.String())
+	return err == nil && addr.Address == f.Value.String()
+}
+
+func validateURL(f ValidationField) bool {
+	u, err := url.Parse(f.Value.String())
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func validateUUID(f ValidationField) bool {
+	return isUUID(f.Value.String())
+}
+
+func validateAlpha(f ValidationField) bool {
+	return isAlpha(f.Value.String())
+}
+
+func validateAlnum(f ValidationField) bool {
+	return isAlnum(f.Value.String())
+}
+
+func validateNumeric(f ValidationField) bool {
+	v := strings.TrimLeft(f.Value.String(), "+-")
+	integer, fraction, hasFraction := strings.Cut(v, ".")
+	return isDigits(integer) && (!hasFraction || isDigits(fraction))
+}
+
+func validateEqField(f ValidationField) bool {
+	c, ok := compareField(f)
+	return ok && c == 0
+}
+
+func validateNeField(f ValidationField) bool {
+	c, ok := compareField(f)
+	return !ok || c != 0
+}
+
+func validateGtField(f ValidationField) bool {
+	c, ok := compareField(f)
+	return ok && c > 0
+}
+
+func validateGteField(f ValidationField) bool {
+	c, ok := compareField(f)
+	return ok && c >= 0
+}
+
+func validateLtField(f ValidationField) bool {
+	c, ok := compareField(f)
+	return ok && c < 0
+}
+
+func validateLteField(f ValidationField) bool {
+	c, ok := compareField(f)
+	return ok && c <= 0
+}
@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validateAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"omitempty,len=5,numeric"`
+}
+
+type validateItem struct {
+	SKU string `json:"sku" validate:"required,alnum"`
+	Qty int    `json:"qty" validate:"min=1,max=100"`
+}
+
+type validateTarget struct {
+	Name     string            `json:"name" validate:"required,min=1,max=8"`
+	Email    string            `json:"email" validate:"omitempty,email"`
+	Role     string            `json:"role" validate:"oneof=admin editor viewer"`
+	Age      *int              `json:"age" validate:"omitempty,min=18"`
+	Address  *validateAddress  `json:"address" validate:"required"`
+	Items    []validateItem    `json:"items" validate:"max=2"`
+	Tags     []string          `json:"tags" validate:"dive,required,alpha"`
+	Labels   map[string]string `json:"labels" validate:"dive,max=3"`
+	Password string            `json:"password"`
+	Confirm  string            `json:"confirm" validate:"eqfield=Password"`
+	Start    time.Time         `json:"start"`
+	End      time.Time         `json:"end" validate:"omitempty,gtfield=Start"`
+	Ignored  validateItem      `json:"ignored" validate:"-"`
+}
+
+func validValidateTarget() validateTarget {
+	return validateTarget{
+		Name:    "Jon",
+		Role:    "admin",
+		Address: &validateAddress{City: "Tallinn"},
+	}
+}
+
+func TestStructValidator_Validate(t *testing.T) {
+	age := 17
+	var testCases = []struct {
+		name         string
+		given        func(v *validateTarget)
+		expectErrors []*ValidationError
+	}{
+		{
+			name:  "ok, valid",
+			given: func(v *validateTarget) {},
+		},
+		{
+			name: "ok, valid with optional fields",
+			given: func(v *validateTarget) {
+				adult := 18
+				v.Email = "jon@example.com"
+				v.Age = &adult
+				v.Address.Zip = "10115"
+				v.Items = []validateItem{{SKU: "A1", Qty: 1}}
+				v.Tags = []string{"go", "web"}
+				v.Labels = map[string]string{"env": "dev"}
+				v.Password = "secret"
+				v.Confirm = "secret"
+				v.Start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				v.End = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+				v.Ignored = validateItem{Qty: -1}
+			},
+		},
+		{
+			name: "nok, required and size rules",
+			given: func(v *validateTarget) {
+				v.Name = ""
+				v.Address = nil
+				v.Role = "root"
+				v.Age = &age
+			},
+			expectErrors: []*ValidationError{
+				{Field: "name", Rule: "required", Message: "is required"},
+				{Field: "role", Rule: "oneof", Param: "admin editor viewer", Message: "must be one of: admin, editor, viewer"},
+				{Field: "age", Rule: "min", Param: "18", Message: "must be at least 18"},
+				{Field: "address", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name: "nok, string formats and length",
+			given: func(v *validateTarget) {
+				v.Name = "Jonathan Doe"
+				v.Email = "Jon <jon@example.com>"
+			},
+			expectErrors: []*ValidationError{
+				{Field: "name", Rule: "max", Param: "8", Message: "must have at most 8 characters"},
+				{Field: "email", Rule: "email", Message: "must be a valid email address"},
+			},
+		},
+		{
+			name: "nok, nested structs and slices",
+			given: func(v *validateTarget) {
+				v.Address.City = ""
+				v.Address.Zip = "1a"
+				v.Items = []validateItem{{SKU: "A1", Qty: 1}, {SKU: "B-2", Qty: 0}}
+			},
+			expectErrors: []*ValidationError{
+				{Field: "address.city", Rule: "required", Message: "is required"},
+				{Field: "address.zip", Rule: "len", Param: "5", Message: "must have exactly 5 characters"},
+				{Field: "items[1].sku", Rule: "alnum", Message: "must contain only letters and digits"},
+				{Field: "items[1].qty", Rule: "min", Param: "1", Message: "must be at least 1"},
+			},
+		},
+		{
+			name: "nok, dive rules and too many elements",
+			given: func(v *validateTarget) {
+				v.Items = make([]validateItem, 3)
+				for i := range v.Items {
+					v.Items[i] = validateItem{SKU: "A", Qty: 1}
+				}
+				v.Tags = []string{"go", "", "web2"}
+				v.Labels = map[string]string{"b": "long", "a": "ok"}
+			},
+			expectErrors: []*ValidationError{
+				{Field: "items", Rule: "max", Param: "2", Message: "must have at most 2 elements"},
+				{Field: "tags[1]", Rule: "required", Message: "is required"},
+				{Field: "tags[2]", Rule: "alpha", Message: "must contain only letters"},
+				{Field: "labels[b]", Rule: "max", Param: "3", Message: "must have at most 3 characters"},
+			},
+		},
+		{
+			name: "nok, cross-field rules",
+			given: func(v *validateTarget) {
+				v.Password = "secret"
+				v.Confirm = "secret2"
+				v.Start = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+				v.End = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			},
+			expectErrors: []*ValidationError{
+				{Field: "confirm", Rule: "eqfield", Param: "Password", Message: "must be equal to password"},
+				{Field: "end", Rule: "gtfield", Param: "Start", Message: "must be greater than start"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target := validValidateTarget()
+			tc.given(&target)
+
+			err := (&StructValidator{}).Validate(&target)
+			if tc.expectErrors == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var vErrs *ValidationErrors
+			if assert.True(t, errors.As(err, &vErrs)) {
+				assert.Equal(t, tc.expectErrors, vErrs.Errors)
+				assert.Equal(t, http.StatusBadRequest, vErrs.StatusCode())
+			}
+		})
+	}
+}
+
+func TestStructValidator_Validate_slice(t *testing.T) {
+	items := []validateItem{{SKU: "A", Qty: 1}, {SKU: "", Qty: 1}}
+
+	err := (&StructValidator{}).Validate(items)
+	assert.EqualError(t, err, "code=400, message=Bad Request, errors=[field=[1].sku, rule=required, message=is required]")
+}
+
+func TestStructValidator_Validate_cyclic(t *testing.T) {
+	type validateNode struct {
+		Name     string                   `json:"name" validate:"required"`
+		Parent   *validateNode            `json:"parent"`
+		Children []*validateNode          `json:"children"`
+		Links    map[string]*validateNode `json:"links"`
+		Any      any                      `json:"any"`
+	}
+	parent := &validateNode{Name: "parent"}
+	child := &validateNode{Parent: parent}
+	parent.Children = []*validateNode{child, parent}
+	parent.Links = map[string]*validateNode{"self": parent, "child": child}
+	self := []any{nil}
+	self[0] = self
+	child.Any = self
+
+	err := (&StructValidator{}).Validate(parent)
+	assert.EqualError(t, err, "code=400, message=Bad Request, errors=[field=children[0].name, rule=required, message=is required; field=links[child].name, rule=required, message=is required]")
+}
+
+func TestStructValidator_Validate_customRule(t *testing.T) {
+	type target struct {
+		SKU string `query:"sku" validate:"required,sku=3"`
+	}
+	v := &StructValidator{
+		Rules: map[string]ValidationRule{
+			"sku": func(f ValidationField) bool {
+				prefix, _, ok := strings.Cut(f.Value.String(), "-")
+				return ok && f.Param == "3" && len(prefix) == 3
+			},
+		},
+	}
+
+	assert.NoError(t, v.Validate(&target{SKU: "ABC-1"}))
+	assert.EqualError(t, v.Validate(&target{SKU: "AB-1"}), "code=400, message=Bad Request, errors=[field=sku, rule=sku, message=failed sku validation]")
+
+	err := (&StructValidator{}).Validate(&target{SKU: "ABC-1"})
+	assert.ErrorIs(t, err, ErrInvalidValidationTag)
+	assert.EqualError(t, err, `invalid validate struct tag value: field sku: unknown rule "sku"`)
+}
+
+func TestStructValidator_Validate_invalidTag(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		given       any
+		expectError string
+	}{
+		{
+			name: "nok, invalid size parameter",
+			given: &struct {
+				Name string `validate:"min=x"`
+			}{},
+			expectError: `invalid validate struct tag value: field Name: rule min has invalid parameter: strconv.ParseFloat: parsing "x": invalid syntax`,
+		},
+		{
+			name: "nok, string rule for number",
+			given: &struct {
+				ID int `validate:"email"`
+			}{},
+			expectError: `invalid validate struct tag value: field ID: rule email can be used only with strings`,
+		},
+		{
+			name: "nok, unknown cross-field",
+			given: &struct {
+				Confirm string `validate:"eqfield=Password"`
+			}{},
+			expectError: `invalid validate struct tag value: field Confirm: rule eqfield refers to unknown field "Password"`,
+		},
+		{
+			name: "nok, dive for non-slice",
+			given: &struct {
+				Name string `validate:"dive,required"`
+			}{},
+			expectError: `invalid validate struct tag value: field Name: rule dive can not be used with string`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&StructValidator{}).Validate(tc.given)
+			assert.ErrorIs(t, err, ErrInvalidValidationTag)
+			assert.EqualError(t, err, tc.expectError)
+		})
+	}
+}
+
+func TestStructValidator_errorResponse(t *testing.T) {
+	type target struct {
+		Name  string `json:"name" validate:"required"`
+		Email string `json:"email" validate:"email"`
+	}
+	var testCases = []struct {
+		name         string
+		givenHandler HTTPErrorHandler
+		expectBody   string
+	}{
+		{
+			name:         "ok, default error handler",
+			givenHandler: DefaultHTTPErrorHandler(false),
+			expectBody: `{"message":"Bad Request","errors":[` +
+				`{"field":"name","rule":"required","message":"is required"},` +
+				`{"field":"email","rule":"email","message":"must be a valid email address"}]}` + "\n",
+		},
+		{
+			name:         "ok, problem details error handler",
+			givenHandler: ProblemDetailsHTTPErrorHandler(false),
+			expectBody: `{"type":"about:blank","title":"Bad Request","status":400,"errors":[` +
+				`{"field":"name","rule":"required","message":"is required"},` +
+				`{"field":"email","rule":"email","message":"must be a valid email address"}]}` + "\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.Validator = &StructValidator{}
+			e.HTTPErrorHandler = tc.givenHandler
+			e.POST("/", func(c *Context) error {
+				var t target
+				if err := c.Bind(&t); err != nil {
+					return err
+				}
+				return c.Validate(&t)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"nope"}`))
+			req.Header.Set(HeaderContentType, MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, tc.expectBody, rec.Body.String())
+		})
+	}
+}
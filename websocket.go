@@ -0,0 +1,737 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"crypto/sha1" // #nosec G505 -- SHA-1 is mandated by RFC 6455 for Sec-WebSocket-Accept
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WebSocket message types. See RFC 6455 section 5.6.
+const (
+	WebSocketTextMessage   = 1
+	WebSocketBinaryMessage = 2
+)
+
+// WebSocket close codes. See RFC 6455 section 7.4.1.
+const (
+	WebSocketCloseNormalClosure      = 1000
+	WebSocketCloseGoingAway          = 1001
+	WebSocketCloseProtocolError      = 1002
+	WebSocketCloseUnsupportedData    = 1003
+	WebSocketCloseNoStatusReceived   = 1005
+	WebSocketCloseAbnormalClosure    = 1006
+	WebSocketCloseInvalidPayload     = 1007
+	WebSocketClosePolicyViolation    = 1008
+	WebSocketCloseMessageTooBig      = 1009
+	WebSocketCloseMandatoryExtension = 1010
+	WebSocketCloseInternalServerErr  = 1011
+)
+
+// DefaultWebSocketReadLimit is default maximum size of a message read from the WebSocket connection.
+const DefaultWebSocketReadLimit = 16 << 20
+
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xa
+
+	wsAcceptGUID             = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	wsMaxControlPayload      = 125
+	wsCloseHandshakeTimeout  = time.Second
+	wsShutdownCloseTimeout   = 5 * time.Second
+	wsPermessageDeflate      = "permessage-deflate"
+	wsPermessageDeflateReply = wsPermessageDeflate + "; server_no_context_takeover; client_no_context_takeover"
+	// wsDeflateTail is appended to compressed message before inflating: sync flush marker removed by the sender
+	// (RFC 7692 section 7.2.2) followed by an empty final block so the inflater reaches EOF.
+	wsDeflateTail = "\x00\x00\xff\xff\x01\x00\x00\xff\xff"
+)
+
+// ErrWebSocketClosed is returned when writing to the WebSocket connection after close frame has been sent.
+var ErrWebSocketClosed = errors.New("websocket: connection is closed")
+
+// flateWriterPools holds flate writers by compression level (offset by flate.HuffmanOnly).
+var flateWriterPools [flate.BestCompression - flate.HuffmanOnly + 1]sync.Pool
+
+var flateReaderPool sync.Pool
+
+// WebSocketConfig is configuration for Context.WebSocketWithConfig.
+type WebSocketConfig struct {
+	// CheckOrigin returns true when request `Origin` header is acceptable. Requests from other origins are rejected
+	// with 403 status.
+	// Optional. By default requests without `Origin` header and requests where `Origin` host matches the request
+	// host are accepted.
+	CheckOrigin func(c *Context) bool
+
+	// Subprotocols are supported application subprotocols in server preference order. First subprotocol that is also
+	// requested by the client (`Sec-WebSocket-Protocol` header) is selected.
+	// Optional. When empty no subprotocol is selected.
+	Subprotocols []string
+
+	// ReadLimit is maximum size of a message (after decompression) read from the connection. When client sends larger
+	// message the connection is closed with WebSocketCloseMessageTooBig code.
+	// Optional. Default value DefaultWebSocketReadLimit, also used when value is negative. Limit can not be disabled.
+	ReadLimit int64
+
+	// EnableCompression enables negotiation of permessage-deflate extension (RFC 7692). Compression contexts are not
+	// kept between messages.
+	// Optional. Default value false.
+	EnableCompression bool
+
+	// CompressionLevel is compress/flate compression level used for messages written to the connection.
+	// Optional. Default value flate.BestSpeed.
+	CompressionLevel int
+}
+
+// WebSocketCloseError is returned by WebSocketConn.ReadMessage when the peer sends close frame.
+type WebSocketCloseError struct {
+	Code   int
+	Reason string
+}
+
+// Error returns error message.
+func (e *WebSocketCloseError) Error() string {
+	if e.Reason == "" {
+		return "websocket: close " + strconv.Itoa(e.Code)
+	}
+	return "websocket: close " + strconv.Itoa(e.Code) + ": " + e.Reason
+}
+
+// WebSocketConn is server side WebSocket connection. One goroutine may read and multiple goroutines may write to the
+// connection concurrently.
+type WebSocketConn struct {
+	conn        net.Conn
+	br          *bufio.Reader
+	subprotocol string
+
+	compress         bool
+	compressionLevel int
+	readLimit        int64
+
+	rmu         sync.Mutex
+	readErr     error
+	pingHandler func(data []byte) error
+	pongHandler func(data []byte) error
+
+	wmu       sync.Mutex
+	wbuf      []byte
+	closeSent bool
+}
+
+type wsFrameHeader struct {
+	fin    bool
+	rsv1   bool
+	opcode byte
+	length int64
+	mask   [4]byte
+}
+
+// WebSocket upgrades the connection to WebSocket (RFC 6455) and calls fn with the connection. Connection is closed when
+// fn returns. When fn returns nil or WebSocketCloseError with normal closure or going away code, WebSocket is closed
+// with normal closure code. Other errors close WebSocket with internal error code and are returned.
+//
+// Failed handshakes return HTTPError (400, 403 or 426) before anything is written to the client so these are handled
+// by the error handler as usual. When server started with StartConfig is shutting down, close frame with going away
+// code is sent to the client.
+func (c *Context) WebSocket(fn func(ws *WebSocketConn) error) error {
+	return c.WebSocketWithConfig(WebSocketConfig{}, fn)
+}
+
+// WebSocketWithConfig upgrades the connection to WebSocket with given configuration. See Context.WebSocket.
+func (c *Context) WebSocketWithConfig(config WebSocketConfig, fn func(ws *WebSocketConn) error) error {
+	ws, err := c.upgradeWebSocket(config)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	if shutdown := serverShutdownSignal(c.Request().Context()); shutdown != nil {
+		wg.Go(func() {
+			select {
+			case <-stop:
+			case <-shutdown:
+				if err := ws.writeClose(WebSocketCloseGoingAway, "server shutting down"); err == nil {
+					_ = ws.conn.SetReadDeadline(time.Now().Add(wsShutdownCloseTimeout))
+				}
+			}
+		})
+	}
+
+	err = fn(ws)
+	close(stop)
+	wg.Wait()
+
+	var ce *WebSocketCloseError
+	if errors.As(err, &ce) && (ce.Code == WebSocketCloseNormalClosure || ce.Code == WebSocketCloseGoingAway || ce.Code == WebSocketCloseNoStatusReceived) {
+		err = nil
+	}
+	code := WebSocketCloseNormalClosure
+	if err != nil {
+		code = WebSocketCloseInternalServerErr
+	}
+	if cErr := ws.Close(code, ""); cErr != nil && err == nil && !errors.Is(cErr, net.ErrClosed) {
+		err = cErr
+	}
+	return err
+}
+
+func (c *Context) upgradeWebSocket(config WebSocketConfig) (*WebSocketConn, error) {
+	req := c.Request()
+	if req.Method != http.MethodGet {
+		return nil, NewHTTPError(http.StatusMethodNotAllowed, "websocket: request method is not GET")
+	}
+	if !req.ProtoAtLeast(1, 1) || !headerContainsToken(req.Header, HeaderConnection, "upgrade") ||
+		!headerContainsToken(req.Header, HeaderUpgrade, "websocket") {
+		return nil, NewHTTPError(http.StatusBadRequest, "websocket: request is not a websocket handshake")
+	}
+	if req.Header.Get("Sec-WebSocket-Version") != "13" {
+		c.Response().Header().Set("Sec-WebSocket-Version", "13")
+		return nil, NewHTTPError(http.StatusUpgradeRequired, "websocket: unsupported version")
+	}
+	key := req.Header.Get("Sec-WebSocket-Key")
+	if k, err := base64.StdEncoding.DecodeString(key); err != nil || len(k) != 16 {
+		return nil, NewHTTPError(http.StatusBadRequest, "websocket: invalid Sec-WebSocket-Key header")
+	}
+	checkOrigin := config.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = isSameOrigin
+	}
+	if !checkOrigin(c) {
+		return nil, NewHTTPError(http.StatusForbidden, "websocket: origin not allowed")
+	}
+
+	ws := &WebSocketConn{
+		subprotocol:      selectWebSocketSubprotocol(req.Header, config.Subprotocols),
+		compress:         config.EnableCompression && acceptPermessageDeflate(req.Header),
+		compressionLevel: config.CompressionLevel,
+		readLimit:        config.ReadLimit,
+	}
+	if ws.readLimit <= 0 {
+		ws.readLimit = DefaultWebSocketReadLimit
+	}
+	if ws.compressionLevel == 0 {
+		ws.compressionLevel = flate.BestSpeed
+	}
+	if ws.compressionLevel < flate.HuffmanOnly || ws.compressionLevel > flate.BestCompression {
+		return nil, fmt.Errorf("websocket: invalid compression level: %d", ws.compressionLevel)
+	}
+
+	conn, brw, err := http.NewResponseController(c.Response()).Hijack()
+	if err != nil {
+		return nil, err
+	}
+	if resp, _ := UnwrapResponse(c.Response()); resp != nil {
+		resp.Status = http.StatusSwitchingProtocols
+		resp.Committed = true
+	}
+	// http.Server read/write timeouts do not apply to the hijacked connection anymore
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	ws.conn = conn
+	ws.br = brw.Reader
+
+	var b bytes.Buffer
+	b.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: ")
+	b.WriteString(webSocketAcceptKey(key))
+	b.WriteString("\r\n")
+	if ws.subprotocol != "" {
+		b.WriteString("Sec-WebSocket-Protocol: " + ws.subprotocol + "\r\n")
+	}
+	if ws.compress {
+		b.WriteString("Sec-WebSocket-Extensions: " + wsPermessageDeflateReply + "\r\n")
+	}
+	// headers set by the middlewares/handler (i.e. cookies) are sent with the handshake response
+	_ = c.Response().Header().WriteSubset(&b, map[string]bool{
+		HeaderConnection:           true,
+		HeaderUpgrade:              true,
+		HeaderContentType:          true,
+		HeaderContentLength:        true,
+		HeaderContentEncoding:      true,
+		"Sec-Websocket-Accept":     true,
+		"Sec-Websocket-Protocol":   true,
+		"Sec-Websocket-Extensions": true,
+	})
+	b.WriteString("\r\n")
+	if _, err := conn.Write(b.Bytes()); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+// Subprotocol returns negotiated subprotocol. Empty string when no subprotocol was selected.
+func (ws *WebSocketConn) Subprotocol() string {
+	return ws.subprotocol
+}
+
+// NetConn returns underlying network connection.
+func (ws *WebSocketConn) NetConn() net.Conn {
+	return ws.conn
+}
+
+// SetReadDeadline sets read deadline of the underlying network connection.
+func (ws *WebSocketConn) SetReadDeadline(t time.Time) error {
+	return ws.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets write deadline of the underlying network connection.
+func (ws *WebSocketConn) SetWriteDeadline(t time.Time) error {
+	return ws.conn.SetWriteDeadline(t)
+}
+
+// SetPingHandler sets handler called from ReadMessage for received ping frames. By default, pong frame with the same
+// data is sent back.
+func (ws *WebSocketConn) SetPingHandler(fn func(data []byte) error) {
+	ws.rmu.Lock()
+	defer ws.rmu.Unlock()
+	ws.pingHandler = fn
+}
+
+// SetPongHandler sets handler called from ReadMessage for received pong frames. By default, pong frames are ignored.
+func (ws *WebSocketConn) SetPongHandler(fn func(data []byte) error) {
+	ws.rmu.Lock()
+	defer ws.rmu.Unlock()
+	ws.pongHandler = fn
+}
+
+// ReadMessage reads next data message from the connection. Control frames are handled while reading. When peer closes
+// the connection WebSocketCloseError is returned. After an error all following calls return the same error.
+func (ws *WebSocketConn) ReadMessage() (messageType int, data []byte, err error) {
+	ws.rmu.Lock()
+	defer ws.rmu.Unlock()
+
+	if ws.readErr != nil {
+		return 0, nil, ws.readErr
+	}
+	messageType, data, err = ws.readMessage()
+	if err != nil {
+		ws.readErr = err
+	}
+	return messageType, data, err
+}
+
+// WriteMessage writes data message to the connection.
+func (ws *WebSocketConn) WriteMessage(messageType int, data []byte) error {
+	if messageType != WebSocketTextMessage && messageType != WebSocketBinaryMessage {
+		return fmt.Errorf("websocket: invalid message type: %d", messageType)
+	}
+	if !ws.compress {
+		return ws.writeFrame(byte(messageType), false, data)
+	}
+	compressed, err := deflateMessage(data, ws.compressionLevel)
+	if err != nil {
+		return err
+	}
+	return ws.writeFrame(byte(messageType), true, compressed)
+}
+
+// Ping writes ping frame to the connection. Data can be at most 125 bytes.
+func (ws *WebSocketConn) Ping(data []byte) error {
+	if len(data) > wsMaxControlPayload {
+		return errors.New("websocket: control frame payload too large")
+	}
+	return ws.writeFrame(wsOpPing, false, data)
+}
+
+// Close sends close frame with given code and reason (unless close frame has already been sent), waits shortly for
+// the peer to reply with close frame and closes the underlying network connection.
+func (ws *WebSocketConn) Close(code int, reason string) error {
+	err := ws.writeClose(code, reason)
+	if err == nil {
+		// wait for the peer close frame to complete the closing handshake
+		_ = ws.conn.SetReadDeadline(time.Now().Add(wsCloseHandshakeTimeout))
+		for {
+			if _, _, rErr := ws.ReadMessage(); rErr != nil {
+				break
+			}
+		}
+	}
+	if cErr := ws.conn.Close(); cErr != nil && (err == nil || errors.Is(err, ErrWebSocketClosed)) {
+		return cErr
+	}
+	if errors.Is(err, ErrWebSocketClosed) {
+		return nil
+	}
+	return err
+}
+
+func (ws *WebSocketConn) readMessage() (int, []byte, error) {
+	var (
+		opcode     byte
+		compressed bool
+		payload    bytes.Buffer
+	)
+	for {
+		h, err := ws.readFrameHeader()
+		if err != nil {
+			return 0, nil, err
+		}
+		if h.opcode >= wsOpClose {
+			if err := ws.handleControlFrame(h); err != nil {
+				return 0, nil, err
+			}
+			continue
+		}
+
+		if h.opcode == wsOpContinuation {
+			if opcode == 0 {
+				return 0, nil, ws.fail(WebSocketCloseProtocolError, "unexpected continuation frame")
+			}
+		} else {
+			if opcode != 0 {
+				return 0, nil, ws.fail(WebSocketCloseProtocolError, "expected continuation frame")
+			}
+			opcode = h.opcode
+			compressed = h.rsv1
+		}
+		// limit applies to all frames of the message. Checked without summing as frame length can be up to 63 bits.
+		n := payload.Len()
+		if h.length > ws.readLimit-int64(n) {
+			return 0, nil, ws.fail(WebSocketCloseMessageTooBig, "message too big")
+		}
+		// payload is read to the buffer that grows while data arrives, so frame length sent by the client does not
+		// decide how much memory is allocated upfront
+		if _, err := io.CopyN(&payload, ws.br, h.length); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, nil, err
+		}
+		maskBytes(h.mask, payload.Bytes()[n:])
+
+		if h.fin {
+			break
+		}
+	}
+
+	message := payload.Bytes()
+	if compressed {
+		var err error
+		if message, err = inflateMessage(message, ws.readLimit); err != nil {
+			if errors.Is(err, errWebSocketMessageTooBig) {
+				return 0, nil, ws.fail(WebSocketCloseMessageTooBig, "message too big")
+			}
+			return 0, nil, ws.fail(WebSocketCloseInvalidPayload, "invalid compressed data")
+		}
+	}
+	if opcode == wsOpText && !utf8.Valid(message) {
+		return 0, nil, ws.fail(WebSocketCloseInvalidPayload, "invalid UTF-8 in text message")
+	}
+	return int(opcode), message, nil
+}
+
+func (ws *WebSocketConn) readFrameHeader() (wsFrameHeader, error) {
+	var h wsFrameHeader
+	var b [8]byte
+	if _, err := io.ReadFull(ws.br, b[:2]); err != nil {
+		return h, err
+	}
+	h.fin = b[0]&0x80 != 0
+	h.rsv1 = b[0]&0x40 != 0
+	h.opcode = b[0] & 0x0f
+	rsv23 := b[0]&0x30 != 0 // b is reused for extended payload length
+	masked := b[1]&0x80 != 0
+	h.length = int64(b[1] & 0x7f)
+
+	switch h.length {
+	case 126:
+		if _, err := io.ReadFull(ws.br, b[:2]); err != nil {
+			return h, err
+		}
+		h.length = int64(binary.BigEndian.Uint16(b[:2]))
+	case 127:
+		if _, err := io.ReadFull(ws.br, b[:8]); err != nil {
+			return h, err
+		}
+		l := binary.BigEndian.Uint64(b[:8])
+		if l > 1<<63-1 {
+			return h, ws.fail(WebSocketCloseProtocolError, "invalid frame length")
+		}
+		h.length = int64(l)
+	}
+	if masked {
+		if _, err := io.ReadFull(ws.br, h.mask[:]); err != nil {
+			return h, err
+		}
+	}
+
+	switch {
+	case !masked:
+		return h, ws.fail(WebSocketCloseProtocolError, "client frame is not masked")
+	case rsv23:
+		return h, ws.fail(WebSocketCloseProtocolError, "unexpected reserved bits")
+	case h.rsv1 && (!ws.compress || h.opcode == wsOpContinuation || h.opcode >= wsOpClose):
+		return h, ws.fail(WebSocketCloseProtocolError, "unexpected reserved bits")
+	}
+	switch h.opcode {
+	case wsOpContinuation, wsOpText, wsOpBinary:
+	case wsOpClose, wsOpPing, wsOpPong:
+		if !h.fin || h.length > wsMaxControlPayload {
+			return h, ws.fail(WebSocketCloseProtocolError, "invalid control frame")
+		}
+	default:
+		return h, ws.fail(WebSocketCloseProtocolError, "unknown opcode "+strconv.Itoa(int(h.opcode)))
+	}
+	return h, nil
+}
+
+func (ws *WebSocketConn) handleControlFrame(h wsFrameHeader) error {
+	payload := make([]byte, h.length)
+	if _, err := io.ReadFull(ws.br, payload); err != nil {
+		return err
+	}
+	maskBytes(h.mask, payload)
+
+	switch h.opcode {
+	case wsOpPing:
+		if ws.pingHandler != nil {
+			return ws.pingHandler(payload)
+		}
+		if err := ws.writeFrame(wsOpPong, false, payload); err != nil && !errors.Is(err, ErrWebSocketClosed) {
+			return err
+		}
+	case wsOpPong:
+		if ws.pongHandler != nil {
+			return ws.pongHandler(payload)
+		}
+	case wsOpClose:
+		ce := &WebSocketCloseError{Code: WebSocketCloseNoStatusReceived}
+		if len(payload) == 1 {
+			return ws.fail(WebSocketCloseProtocolError, "invalid close frame")
+		}
+		if len(payload) >= 2 {
+			ce.Code = int(binary.BigEndian.Uint16(payload))
+			ce.Reason = string(payload[2:])
+			if !isValidReceivedCloseCode(ce.Code) {
+				return ws.fail(WebSocketCloseProtocolError, "invalid close code")
+			}
+			if !utf8.ValidString(ce.Reason) {
+				return ws.fail(WebSocketCloseInvalidPayload, "invalid UTF-8 in close reason")
+			}
+		}
+		// echo close frame back to complete the closing handshake
+		replyCode := ce.Code
+		if replyCode == WebSocketCloseNoStatusReceived {
+			replyCode = 0
+		}
+		if err := ws.writeClose(replyCode, ""); err != nil && !errors.Is(err, ErrWebSocketClosed) {
+			return err
+		}
+		return ce
+	}
+	return nil
+}
+
+// fail sends close frame with given code to the peer and returns error describing the failure.
+func (ws *WebSocketConn) fail(code int, reason string) error {
+	_ = ws.writeClose(code, reason)
+	return errors.New("websocket: " + reason)
+}
+
+// writeClose writes close frame. Code 0 writes close frame without body.
+func (ws *WebSocketConn) writeClose(code int, reason string) error {
+	var payload []byte
+	if code != 0 {
+		if len(reason) > wsMaxControlPayload-2 {
+			reason = reason[:wsMaxControlPayload-2]
+		}
+		payload = binary.BigEndian.AppendUint16(make([]byte, 0, 2+len(reason)), uint16(code))
+		payload = append(payload, reason...)
+	}
+	return ws.writeFrame(wsOpClose, false, payload)
+}
+
+func (ws *WebSocketConn) writeFrame(opcode byte, rsv1 bool, payload []byte) error {
+	ws.wmu.Lock()
+	defer ws.wmu.Unlock()
+
+	if ws.closeSent {
+		return ErrWebSocketClosed
+	}
+	b0 := 0x80 | opcode
+	if rsv1 {
+		b0 |= 0x40
+	}
+	b := append(ws.wbuf[:0], b0)
+	switch l := len(payload); {
+	case l <= 125:
+		b = append(b, byte(l))
+	case l <= 0xffff:
+		b = append(b, 126)
+		b = binary.BigEndian.AppendUint16(b, uint16(l))
+	default:
+		b = append(b, 127)
+		b = binary.BigEndian.AppendUint64(b, uint64(l))
+	}
+	b = append(b, payload...)
+	ws.wbuf = b
+	if opcode == wsOpClose {
+		ws.closeSent = true
+	}
+	_, err := ws.conn.Write(b)
+	return err
+}
+
+func maskBytes(mask [4]byte, b []byte) {
+	for i := range b {
+		b[i] ^= mask[i&3]
+	}
+}
+
+func isValidReceivedCloseCode(code int) bool {
+	switch {
+	case code >= 1000 && code <= 1003, code >= 1007 && code <= 1014:
+		return true
+	case code >= 3000 && code <= 4999:
+		return true
+	}
+	return false
+}
+
+var errWebSocketMessageTooBig = errors.New("websocket: message too big")
+
+func deflateMessage(data []byte, level int) ([]byte, error) {
+	var buf bytes.Buffer
+	pool := &flateWriterPools[level-flate.HuffmanOnly]
+	fw, _ := pool.Get().(*flate.Writer)
+	if fw == nil {
+		var err error
+		if fw, err = flate.NewWriter(&buf, level); err != nil {
+			return nil, err
+		}
+	} else {
+		fw.Reset(&buf)
+	}
+	defer pool.Put(fw)
+
+	if _, err := fw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := fw.Flush(); err != nil {
+		return nil, err
+	}
+	// remove sync flush marker, see RFC 7692 section 7.2.1
+	return bytes.TrimSuffix(buf.Bytes(), []byte{0x00, 0x00, 0xff, 0xff}), nil
+}
+
+func inflateMessage(data []byte, limit int64) ([]byte, error) {
+	src := io.MultiReader(bytes.NewReader(data), strings.NewReader(wsDeflateTail))
+	fr, _ := flateReaderPool.Get().(io.ReadCloser)
+	if fr == nil {
+		fr = flate.NewReader(src)
+	} else if err := fr.(flate.Resetter).Reset(src, nil); err != nil {
+		return nil, err
+	}
+	defer flateReaderPool.Put(fr)
+
+	result, err := io.ReadAll(io.LimitReader(fr, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(result)) > limit {
+		return nil, errWebSocketMessageTooBig
+	}
+	return result, nil
+}
+
+func webSocketAcceptKey(key string) string {
+	h := sha1.New() // #nosec G401 -- SHA-1 is mandated by RFC 6455
+	h.Write([]byte(key))
+	h.Write([]byte(wsAcceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// isSameOrigin checks that request has no Origin header or Origin host is equal to the request host.
+func isSameOrigin(c *Context) bool {
+	origin := c.Request().Header.Get(HeaderOrigin)
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, c.Request().Host)
+}
+
+func selectWebSocketSubprotocol(header http.Header, supported []string) string {
+	if len(supported) == 0 {
+		return ""
+	}
+	var requested []string
+	for _, v := range header.Values("Sec-WebSocket-Protocol") {
+		for p := range strings.SplitSeq(v, ",") {
+			requested = append(requested, strings.TrimSpace(p))
+		}
+	}
+	for _, s := range supported {
+		for _, r := range requested {
+			if s == r {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// acceptPermessageDeflate checks if client offers permessage-deflate extension with parameters server can accept.
+// Offers limiting server window size are not accepted as compress/flate always uses 32KB window.
+func acceptPermessageDeflate(header http.Header) bool {
+	for _, v := range header.Values("Sec-WebSocket-Extensions") {
+	offers:
+		for offer := range strings.SplitSeq(v, ",") {
+			name, params, _ := strings.Cut(offer, ";")
+			if !strings.EqualFold(strings.TrimSpace(name), wsPermessageDeflate) {
+				continue
+			}
+			for param := range strings.SplitSeq(params, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				switch strings.ToLower(strings.TrimSpace(key)) {
+				case "", "server_no_context_takeover", "client_no_context_takeover", "client_max_window_bits":
+				case "server_max_window_bits":
+					if strings.Trim(strings.TrimSpace(value), `"`) != "15" {
+						continue offers
+					}
+				default:
+					continue offers
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// headerContainsToken checks if comma separated header values contain given token (case-insensitive).
+func headerContainsToken(header http.Header, name string, token string) bool {
+	for _, v := range header.Values(name) {
+		for t := range strings.SplitSeq(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
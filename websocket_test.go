@@ -0,0 +1,554 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: © 2015 LabStack LLC and Echo contributors
+
+package echo
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"compress/flate"
+	stdContext "context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type wsTestClient struct {
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+func dialTestWebSocket(t *testing.T, addr string, path string, header http.Header) (*wsTestClient, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://"+addr+path, nil)
+	req.Header.Set(HeaderConnection, "Upgrade")
+	req.Header.Set(HeaderUpgrade, "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if err := req.Write(conn); err != nil {
+		t.Fatal(err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &wsTestClient{conn: conn, br: br}, resp
+}
+
+func (c *wsTestClient) writeFrame(fin bool, rsv1 bool, opcode byte, payload []byte) error {
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+	if rsv1 {
+		b0 |= 0x40
+	}
+	b := []byte{b0}
+	switch l := len(payload); {
+	case l <= 125:
+		b = append(b, 0x80|byte(l))
+	case l <= 0xffff:
+		b = append(b, 0x80|126)
+		b = binary.BigEndian.AppendUint16(b, uint16(l))
+	default:
+		b = append(b, 0x80|127)
+		b = binary.BigEndian.AppendUint64(b, uint64(l))
+	}
+	mask := [4]byte{1, 2, 3, 4}
+	b = append(b, mask[:]...)
+	masked := bytes.Clone(payload)
+	maskBytes(mask, masked)
+	_, err := c.conn.Write(append(b, masked...))
+	return err
+}
+
+func (c *wsTestClient) readFrame() (opcode byte, rsv1 bool, payload []byte, err error) {
+	var h [2]byte
+	if _, err := io.ReadFull(c.br, h[:]); err != nil {
+		return 0, false, nil, err
+	}
+	if h[1]&0x80 != 0 {
+		return 0, false, nil, errors.New("server frame is masked")
+	}
+	length := int(h[1] & 0x7f)
+	switch length {
+	case 126:
+		var l [2]byte
+		if _, err := io.ReadFull(c.br, l[:]); err != nil {
+			return 0, false, nil, err
+		}
+		length = int(binary.BigEndian.Uint16(l[:]))
+	case 127:
+		var l [8]byte
+		if _, err := io.ReadFull(c.br, l[:]); err != nil {
+			return 0, false, nil, err
+		}
+		length = int(binary.BigEndian.Uint64(l[:]))
+	}
+	payload = make([]byte, length)
+	_, err = io.ReadFull(c.br, payload)
+	return h[0] & 0x0f, h[0]&0x40 != 0, payload, err
+}
+
+func (c *wsTestClient) expectClose(t *testing.T, code int) {
+	t.Helper()
+	opcode, _, payload, err := c.readFrame()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(wsOpClose), opcode)
+	if assert.GreaterOrEqual(t, len(payload), 2) {
+		assert.Equal(t, code, int(binary.BigEndian.Uint16(payload)))
+	}
+}
+
+func closePayload(code int, reason string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(code)), reason...)
+}
+
+func startWebSocketTestServer(t *testing.T, config WebSocketConfig, fn func(ws *WebSocketConn) error) (string, <-chan error) {
+	t.Helper()
+	handlerErr := make(chan error, 1)
+	e := New()
+	e.GET("/ws", func(c *Context) error {
+		c.Response().Header().Set("X-Test", "1")
+		err := c.WebSocketWithConfig(config, fn)
+		handlerErr <- err
+		return err
+	})
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().String(), handlerErr
+}
+
+func echoWebSocketMessages(ws *WebSocketConn) error {
+	for {
+		typ, data, err := ws.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if err := ws.WriteMessage(typ, data); err != nil {
+			return err
+		}
+	}
+}
+
+func TestWebSocketAcceptKey(t *testing.T) {
+	// example from RFC 6455 section 1.3
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", webSocketAcceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func TestContext_WebSocket_handshakeErrors(t *testing.T) {
+	var testCases = []struct {
+		name          string
+		whenMethod    string
+		whenHeaders   map[string]string
+		expectCode    int
+		expectVersion string
+	}{
+		{
+			name: "nok, not upgrade request",
+			whenHeaders: map[string]string{
+				HeaderConnection: "keep-alive",
+			},
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			name:       "nok, method is not GET",
+			whenMethod: http.MethodPost,
+			expectCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:          "nok, unsupported version",
+			whenHeaders:   map[string]string{"Sec-WebSocket-Version": "8"},
+			expectCode:    http.StatusUpgradeRequired,
+			expectVersion: "13",
+		},
+		{
+			name:        "nok, invalid key",
+			whenHeaders: map[string]string{"Sec-WebSocket-Key": "short"},
+			expectCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "nok, cross origin",
+			whenHeaders: map[string]string{HeaderOrigin: "https://evil.example.com"},
+			expectCode:  http.StatusForbidden,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := New()
+			e.Any("/ws", func(c *Context) error {
+				return c.WebSocket(func(ws *WebSocketConn) error {
+					return errors.New("should not be called")
+				})
+			})
+
+			req := httptest.NewRequest(cmp.Or(tc.whenMethod, http.MethodGet), "http://example.com/ws", nil)
+			req.Header.Set(HeaderConnection, "keep-alive, Upgrade")
+			req.Header.Set(HeaderUpgrade, "websocket")
+			req.Header.Set("Sec-WebSocket-Version", "13")
+			req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			for k, v := range tc.whenHeaders {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectCode, rec.Code)
+			assert.Equal(t, tc.expectVersion, rec.Header().Get("Sec-WebSocket-Version"))
+		})
+	}
+}
+
+func TestContext_WebSocket(t *testing.T) {
+	addr, handlerErr := startWebSocketTestServer(t, WebSocketConfig{Subprotocols: []string{"v2", "v1"}}, echoWebSocketMessages)
+
+	client, resp := dialTestWebSocket(t, addr, "/ws", http.Header{
+		"Sec-Websocket-Protocol": {"v1, v2"},
+		"Origin":                 {"http://" + addr},
+	})
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+	assert.Equal(t, "v2", resp.Header.Get("Sec-WebSocket-Protocol"))
+	assert.Equal(t, "", resp.Header.Get("Sec-WebSocket-Extensions"))
+	assert.Equal(t, "1", resp.Header.Get("X-Test"))
+
+	// text message
+	assert.NoError(t, client.writeFrame(true, false, wsOpText, []byte("hello")))
+	opcode, _, payload, err := client.readFrame()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(wsOpText), opcode)
+	assert.Equal(t, "hello", string(payload))
+
+	// fragmented binary message with interleaved ping
+	large := bytes.Repeat([]byte{0xff}, 70000)
+	assert.NoError(t, client.writeFrame(false, false, wsOpBinary, large[:200]))
+	assert.NoError(t, client.writeFrame(true, false, wsOpPing, []byte("ping")))
+	assert.NoError(t, client.writeFrame(true, false, wsOpContinuation, large[200:]))
+
+	opcode, _, payload, err = client.readFrame()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(wsOpPong), opcode)
+	assert.Equal(t, "ping", string(payload))
+
+	opcode, _, payload, err = client.readFrame()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(wsOpBinary), opcode)
+	assert.Equal(t, large, payload)
+
+	// closing handshake
+	assert.NoError(t, client.writeFrame(true, false, wsOpClose, closePayload(WebSocketCloseNormalClosure, "bye")))
+	client.expectClose(t, WebSocketCloseNormalClosure)
+
+	assert.NoError(t, <-handlerErr)
+	_, _, _, err = client.readFrame()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestContext_WebSocket_protocolErrors(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		givenConfig WebSocketConfig
+		whenFrames  func(c *wsTestClient) error
+		expectCode  int
+		expectError string
+	}{
+		{
+			name: "nok, message too big",
+			givenConfig: WebSocketConfig{
+				ReadLimit: 10,
+			},
+			whenFrames: func(c *wsTestClient) error {
+				return c.writeFrame(true, false, wsOpText, []byte("more than ten bytes"))
+			},
+			expectCode:  WebSocketCloseMessageTooBig,
+			expectError: "websocket: message too big",
+		},
+		{
+			name: "nok, fragmented message too big",
+			givenConfig: WebSocketConfig{
+				ReadLimit: 10,
+			},
+			whenFrames: func(c *wsTestClient) error {
+				if err := c.writeFrame(false, false, wsOpText, []byte("123456")); err != nil {
+					return err
+				}
+				return c.writeFrame(true, false, wsOpContinuation, []byte("789012"))
+			},
+			expectCode:  WebSocketCloseMessageTooBig,
+			expectError: "websocket: message too big",
+		},
+		{
+			name: "nok, negative read limit does not disable the limit",
+			givenConfig: WebSocketConfig{
+				ReadLimit: -1,
+			},
+			whenFrames: func(c *wsTestClient) error {
+				// frame header claiming maximum 63-bit payload length without the payload
+				b := []byte{0x80 | wsOpBinary, 0x80 | 127}
+				b = binary.BigEndian.AppendUint64(b, 1<<63-1)
+				_, err := c.conn.Write(append(b, 1, 2, 3, 4))
+				return err
+			},
+			expectCode:  WebSocketCloseMessageTooBig,
+			expectError: "websocket: message too big",
+		},
+		{
+			name: "nok, continuation frame length overflows message size",
+			givenConfig: WebSocketConfig{
+				ReadLimit: 10,
+			},
+			whenFrames: func(c *wsTestClient) error {
+				if err := c.writeFrame(false, false, wsOpText, []byte("123456")); err != nil {
+					return err
+				}
+				b := []byte{wsOpContinuation, 0x80 | 127}
+				b = binary.BigEndian.AppendUint64(b, 1<<63-1)
+				_, err := c.conn.Write(append(b, 1, 2, 3, 4))
+				return err
+			},
+			expectCode:  WebSocketCloseMessageTooBig,
+			expectError: "websocket: message too big",
+		},
+		{
+			name: "nok, invalid utf-8",
+			whenFrames: func(c *wsTestClient) error {
+				return c.writeFrame(true, false, wsOpText, []byte{0xff, 0xfe})
+			},
+			expectCode:  WebSocketCloseInvalidPayload,
+			expectError: "websocket: invalid UTF-8 in text message",
+		},
+		{
+			name: "nok, unexpected continuation",
+			whenFrames: func(c *wsTestClient) error {
+				return c.writeFrame(true, false, wsOpContinuation, []byte("x"))
+			},
+			expectCode:  WebSocketCloseProtocolError,
+			expectError: "websocket: unexpected continuation frame",
+		},
+		{
+			name: "nok, compressed frame without negotiated compression",
+			whenFrames: func(c *wsTestClient) error {
+				return c.writeFrame(true, true, wsOpText, []byte("x"))
+			},
+			expectCode:  WebSocketCloseProtocolError,
+			expectError: "websocket: unexpected reserved bits",
+		},
+		{
+			name: "nok, fragmented control frame",
+			whenFrames: func(c *wsTestClient) error {
+				return c.writeFrame(false, false, wsOpPing, []byte("x"))
+			},
+			expectCode:  WebSocketCloseProtocolError,
+			expectError: "websocket: invalid control frame",
+		},
+		{
+			name: "nok, unmasked frame",
+			whenFrames: func(c *wsTestClient) error {
+				_, err := c.conn.Write([]byte{0x81, 0x01, 'x'})
+				return err
+			},
+			expectCode:  WebSocketCloseProtocolError,
+			expectError: "websocket: client frame is not masked",
+		},
+		{
+			name: "nok, invalid close code",
+			whenFrames: func(c *wsTestClient) error {
+				return c.writeFrame(true, false, wsOpClose, closePayload(1005, ""))
+			},
+			expectCode:  WebSocketCloseProtocolError,
+			expectError: "websocket: invalid close code",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var readErr error
+			addr, handlerErr := startWebSocketTestServer(t, tc.givenConfig, func(ws *WebSocketConn) error {
+				_, _, readErr = ws.ReadMessage()
+				return nil
+			})
+			client, resp := dialTestWebSocket(t, addr, "/ws", nil)
+			assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+			assert.NoError(t, tc.whenFrames(client))
+			client.expectClose(t, tc.expectCode)
+
+			assert.NoError(t, <-handlerErr)
+			assert.EqualError(t, readErr, tc.expectError)
+		})
+	}
+}
+
+func TestContext_WebSocket_handlerError(t *testing.T) {
+	addr, handlerErr := startWebSocketTestServer(t, WebSocketConfig{}, func(ws *WebSocketConn) error {
+		return errors.New("handler failed")
+	})
+	client, _ := dialTestWebSocket(t, addr, "/ws", nil)
+
+	client.expectClose(t, WebSocketCloseInternalServerErr)
+	assert.NoError(t, client.writeFrame(true, false, wsOpClose, closePayload(WebSocketCloseInternalServerErr, "")))
+
+	assert.EqualError(t, <-handlerErr, "handler failed")
+}
+
+func TestContext_WebSocket_serverPing(t *testing.T) {
+	pong := make(chan string, 1)
+	addr, handlerErr := startWebSocketTestServer(t, WebSocketConfig{}, func(ws *WebSocketConn) error {
+		ws.SetPongHandler(func(data []byte) error {
+			pong <- string(data)
+			return nil
+		})
+		if err := ws.Ping([]byte("are you there")); err != nil {
+			return err
+		}
+		_, _, err := ws.ReadMessage()
+		return err
+	})
+	client, _ := dialTestWebSocket(t, addr, "/ws", nil)
+
+	opcode, _, payload, err := client.readFrame()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(wsOpPing), opcode)
+	assert.NoError(t, client.writeFrame(true, false, wsOpPong, payload))
+	assert.Equal(t, "are you there", <-pong)
+
+	assert.NoError(t, client.writeFrame(true, false, wsOpClose, nil))
+	opcode, _, payload, err = client.readFrame()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(wsOpClose), opcode)
+	assert.Empty(t, payload)
+	assert.NoError(t, <-handlerErr)
+}
+
+func TestContext_WebSocket_compression(t *testing.T) {
+	addr, handlerErr := startWebSocketTestServer(t, WebSocketConfig{EnableCompression: true}, echoWebSocketMessages)
+	client, resp := dialTestWebSocket(t, addr, "/ws", http.Header{
+		"Sec-Websocket-Extensions": {"permessage-deflate; server_max_window_bits=10, permessage-deflate; client_max_window_bits"},
+	})
+	assert.Equal(t, wsPermessageDeflateReply, resp.Header.Get("Sec-WebSocket-Extensions"))
+
+	message := strings.Repeat("compress me ", 100)
+	compressed, err := deflateMessage([]byte(message), flate.BestCompression)
+	assert.NoError(t, err)
+	assert.Less(t, len(compressed), len(message))
+	assert.NoError(t, client.writeFrame(true, true, wsOpText, compressed))
+
+	opcode, rsv1, payload, err := client.readFrame()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(wsOpText), opcode)
+	assert.True(t, rsv1)
+	inflated, err := inflateMessage(payload, DefaultWebSocketReadLimit)
+	assert.NoError(t, err)
+	assert.Equal(t, message, string(inflated))
+
+	// uncompressed messages are still allowed
+	assert.NoError(t, client.writeFrame(true, false, wsOpBinary, []byte("plain")))
+	_, rsv1, payload, err = client.readFrame()
+	assert.NoError(t, err)
+	assert.True(t, rsv1)
+	inflated, err = inflateMessage(payload, DefaultWebSocketReadLimit)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", string(inflated))
+
+	assert.NoError(t, client.writeFrame(true, false, wsOpClose, closePayload(WebSocketCloseGoingAway, "")))
+	client.expectClose(t, WebSocketCloseGoingAway)
+	assert.NoError(t, <-handlerErr)
+}
+
+func TestAcceptPermessageDeflate(t *testing.T) {
+	var testCases = []struct {
+		when   string
+		expect bool
+	}{
+		{when: "permessage-deflate", expect: true},
+		{when: "x-webkit-deflate-frame, permessage-deflate; client_max_window_bits", expect: true},
+		{when: `permessage-deflate; server_max_window_bits="15"`, expect: true},
+		{when: "permessage-deflate; server_max_window_bits=10", expect: false},
+		{when: "permessage-deflate; unknown_param", expect: false},
+		{when: "x-webkit-deflate-frame", expect: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.when, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("Sec-WebSocket-Extensions", tc.when)
+			assert.Equal(t, tc.expect, acceptPermessageDeflate(h))
+		})
+	}
+}
+
+func TestInflateMessage_readLimit(t *testing.T) {
+	compressed, err := deflateMessage(bytes.Repeat([]byte("a"), 1000), flate.BestSpeed)
+	assert.NoError(t, err)
+
+	_, err = inflateMessage(compressed, 999)
+	assert.ErrorIs(t, err, errWebSocketMessageTooBig)
+
+	result, err := inflateMessage(compressed, 1000)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1000)
+}
+
+func TestContext_WebSocket_gracefulShutdown(t *testing.T) {
+	e := New()
+	e.Logger = slog.New(slog.DiscardHandler)
+	handlerErr := make(chan error, 1)
+	e.GET("/ws", func(c *Context) error {
+		err := c.WebSocket(echoWebSocketMessages)
+		handlerErr <- err
+		return err
+	})
+
+	ctx, shutdown := stdContext.WithCancel(stdContext.Background())
+	defer shutdown()
+	addrChan := make(chan string)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- StartConfig{
+			Address:         ":0",
+			HideBanner:      true,
+			HidePort:        true,
+			GracefulTimeout: time.Second,
+			ListenerAddrFunc: func(addr net.Addr) {
+				addrChan <- addr.String()
+			},
+		}.Start(ctx, e)
+	}()
+	addr, err := waitForServerStart(addrChan, errCh)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	client, resp := dialTestWebSocket(t, addr, "/ws", nil)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.NoError(t, client.writeFrame(true, false, wsOpText, []byte("hi")))
+	_, _, payload, err := client.readFrame()
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", string(payload))
+
+	shutdown()
+
+	client.expectClose(t, WebSocketCloseGoingAway)
+	assert.NoError(t, client.writeFrame(true, false, wsOpClose, closePayload(WebSocketCloseGoingAway, "")))
+	assert.NoError(t, <-handlerErr)
+	assert.NoError(t, <-errCh)
+}